@@ -0,0 +1,95 @@
+package carbon
+
+import "time"
+
+// LeapDayPolicy 2 月 29 日出生时平年的生日
+type LeapDayPolicy int
+
+const (
+	// LeapDayFeb28 平年在 2 月 28 日过生日，与 DiffForHumans 按日历计算的年数一致
+	LeapDayFeb28 LeapDayPolicy = iota
+	// LeapDayMar1 平年在 3 月 1 日过生日
+	LeapDayMar1
+)
+
+// anniversaryIn 返回 year 年的周年日零时，按 policy 处理平年的 2 月 29 日
+func (c *Carbon) anniversaryIn(year int, policy []LeapDayPolicy) *Carbon {
+	date := c.dateOf(year, c.time.Month(), c.time.Day())
+	// 平年的 2 月 29 日顺延为 3 月 1 日
+	if date.time.Month() != c.time.Month() && (len(policy) == 0 || policy[0] == LeapDayFeb28) {
+		return c.dateOf(year, time.February, 28)
+	}
+	return date
+}
+
+// referenceOf 返回 c 所在时区的参照时间，ref 为 nil 时为现在
+func (c *Carbon) referenceOf(ref *Carbon) time.Time {
+	if ref == nil {
+		return time.Now().In(c.time.Location())
+	}
+	return ref.time.In(c.time.Location())
+}
+
+// Age 返回到现在的周岁
+func (c *Carbon) Age(policy ...LeapDayPolicy) int {
+	return c.AgeAt(nil, policy...)
+}
+
+// AgeAt 返回到 ref 时的周岁，按日历年计算，生日当天增加一岁，ref 早于出生日期时返回 0，ref 为 nil 时为现在
+func (c *Carbon) AgeAt(ref *Carbon, policy ...LeapDayPolicy) int {
+	r := c.referenceOf(ref)
+	age := r.Year() - c.time.Year()
+	if daysBetween(c.anniversaryIn(r.Year(), policy).time, r) < 0 {
+		age--
+	}
+	if age < 0 {
+		return 0
+	}
+	return age
+}
+
+// IsBirthday 判断 ref 当天是否是生日，按 c 所在时区的日期比较，ref 为 nil 时为今天
+func (c *Carbon) IsBirthday(ref *Carbon, policy ...LeapDayPolicy) bool {
+	r := c.referenceOf(ref)
+	return daysBetween(c.anniversaryIn(r.Year(), policy).time, r) == 0
+}
+
+// NextAnniversary 返回从今天开始的下一个周年日零时，今天是周年日时返回今天
+func (c *Carbon) NextAnniversary(policy ...LeapDayPolicy) *Carbon {
+	return c.NextAnniversaryAt(nil, policy...)
+}
+
+// NextAnniversaryAt 返回从 ref 当天开始的下一个周年日零时，ref 为 nil 时为今天
+func (c *Carbon) NextAnniversaryAt(ref *Carbon, policy ...LeapDayPolicy) *Carbon {
+	r := c.referenceOf(ref)
+	next := c.anniversaryIn(r.Year(), policy)
+	if daysBetween(r, next.time) < 0 {
+		next = c.anniversaryIn(r.Year()+1, policy)
+	}
+	return next
+}
+
+// DaysUntilBirthday 返回距离下一个生日的天数，今天是生日时返回 0
+func (c *Carbon) DaysUntilBirthday(policy ...LeapDayPolicy) int {
+	return c.DaysUntilBirthdayAt(nil, policy...)
+}
+
+// DaysUntilBirthdayAt 返回从 ref 当天到下一个生日的天数，ref 为 nil 时为今天
+func (c *Carbon) DaysUntilBirthdayAt(ref *Carbon, policy ...LeapDayPolicy) int {
+	return daysBetween(c.referenceOf(ref), c.NextAnniversaryAt(ref, policy...).time)
+}
+
+// NominalAge 返回现在的虚岁
+func (c *Carbon) NominalAge(boundary ...YearBoundary) int {
+	return c.NominalAgeAt(nil, boundary...)
+}
+
+// NominalAgeAt 返回到 ref 时的虚岁，出生时为一岁，默认每到农历正月初一增加一岁，
+// 也可以指定 StartOfSpring 在立春时增加，ref 早于出生时间时返回 0，ref 为 nil 时为现在
+func (c *Carbon) NominalAgeAt(ref *Carbon, boundary ...YearBoundary) int {
+	r := CreateFromGo(c.referenceOf(ref))
+	if r.time.Before(c.time) {
+		return 0
+	}
+	return r.ganZhiYearOf(boundary) - c.ganZhiYearOf(boundary) + 1
+}
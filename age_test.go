@@ -0,0 +1,87 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarbon_AgeAt(t *testing.T) {
+	as := assert.New(t)
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	birth := Create(1990, 4, 12, 10, 0, 0, shanghai)
+	as.Equal(28, birth.AgeAt(Create(2019, 4, 11, 23, 59, 59, shanghai)))
+	as.Equal(29, birth.AgeAt(Create(2019, 4, 12, 0, 0, 0, shanghai)))
+	as.Equal(0, birth.AgeAt(Create(1990, 4, 12, 0, 0, 0, shanghai)))
+	as.Equal(0, birth.AgeAt(Create(1989, 1, 1, 0, 0, 0, shanghai)))
+	// 按出生地时区计算，UTC 4 月 11 日 20:00 为上海 4 月 12 日
+	utc := CreateFromGo(time.Date(2019, 4, 11, 20, 0, 0, 0, time.UTC))
+	as.Equal(29, birth.AgeAt(utc))
+	as.True(birth.IsBirthday(utc))
+	as.False(birth.IsBirthday(Create(2019, 4, 13, 0, 0, 0, shanghai)))
+
+	// 生日当天晚于出生时刻时仍为当天
+	day := Create(2019, 4, 12, 23, 0, 0, shanghai)
+	as.True(birth.IsBirthday(day))
+	as.Equal(0, birth.DaysUntilBirthdayAt(day))
+	as.Equal("2019-04-12", birth.NextAnniversaryAt(day).ToDateString())
+	as.Equal(0, birth.AgeAt(birth))
+}
+
+func TestCarbon_LeapDayBirthday(t *testing.T) {
+	as := assert.New(t)
+	birth := Create(2000, 2, 29, 8, 0, 0, time.UTC)
+	feb28, mar1 := Create(2019, 2, 28, 12, 0, 0, time.UTC), Create(2019, 3, 1, 12, 0, 0, time.UTC)
+
+	as.Equal(19, birth.AgeAt(feb28))
+	as.Equal(18, birth.AgeAt(feb28, LeapDayMar1))
+	as.Equal(19, birth.AgeAt(mar1, LeapDayMar1))
+	as.True(birth.IsBirthday(feb28))
+	as.False(birth.IsBirthday(mar1))
+	as.False(birth.IsBirthday(feb28, LeapDayMar1))
+	as.True(birth.IsBirthday(mar1, LeapDayMar1))
+
+	// 闰年按 2 月 29 日
+	as.True(birth.IsBirthday(Create(2020, 2, 29, 0, 0, 0, time.UTC), LeapDayMar1))
+	as.False(birth.IsBirthday(Create(2020, 2, 28, 0, 0, 0, time.UTC)))
+	as.Equal(19, birth.AgeAt(Create(2020, 2, 28, 0, 0, 0, time.UTC)))
+	as.Equal(20, birth.AgeAt(Create(2020, 2, 29, 0, 0, 0, time.UTC)))
+}
+
+func TestCarbon_NextAnniversary(t *testing.T) {
+	as := assert.New(t)
+	wedding := Create(2010, 4, 12, 18, 30, 0, time.UTC)
+	as.Equal("2019-04-12 00:00:00", wedding.NextAnniversaryAt(Create(2019, 4, 12, 23, 0, 0, time.UTC)).ToDateTimeString())
+	as.Equal("2020-04-12 00:00:00", wedding.NextAnniversaryAt(Create(2019, 4, 13, 0, 0, 0, time.UTC)).ToDateTimeString())
+	as.Equal(1, wedding.DaysUntilBirthdayAt(Create(2019, 4, 11, 23, 0, 0, time.UTC)))
+	as.Equal(0, wedding.DaysUntilBirthdayAt(Create(2019, 4, 12, 23, 0, 0, time.UTC)))
+	as.Equal(365, wedding.DaysUntilBirthdayAt(Create(2019, 4, 13, 0, 0, 0, time.UTC)))
+
+	leap := Create(2000, 2, 29, 0, 0, 0, time.UTC)
+	jan := Create(2019, 1, 1, 0, 0, 0, time.UTC)
+	as.Equal("2019-02-28", leap.NextAnniversaryAt(jan).ToDateString())
+	as.Equal("2019-03-01", leap.NextAnniversaryAt(jan, LeapDayMar1).ToDateString())
+	as.Equal("2020-02-29", leap.NextAnniversaryAt(Create(2019, 3, 1, 0, 0, 0, time.UTC)).ToDateString())
+	as.Equal(59, leap.DaysUntilBirthdayAt(jan, LeapDayMar1))
+}
+
+func TestCarbon_NominalAge(t *testing.T) {
+	as := assert.New(t)
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	// 2019-01-20 为农历戊戌年腊月十五，2019-02-05 为己亥年正月初一
+	birth := Create(2019, 1, 20, 10, 0, 0, shanghai)
+	as.Equal(1, birth.NominalAgeAt(Create(2019, 2, 4, 23, 0, 0, shanghai)))
+	as.Equal(2, birth.NominalAgeAt(Create(2019, 2, 5, 0, 0, 0, shanghai)))
+	as.Equal(0, birth.NominalAgeAt(Create(2019, 1, 20, 9, 0, 0, shanghai)))
+	// 2019 年立春为 2 月 4 日 11:14
+	as.Equal(1, birth.NominalAgeAt(Create(2019, 2, 4, 11, 0, 0, shanghai), StartOfSpring))
+	as.Equal(2, birth.NominalAgeAt(Create(2019, 2, 4, 12, 0, 0, shanghai), StartOfSpring))
+
+	// 2020-01-25 为庚子年正月初一
+	birth = Create(2019, 2, 5, 10, 0, 0, shanghai)
+	as.Equal(1, birth.NominalAgeAt(Create(2020, 1, 24, 0, 0, 0, shanghai)))
+	as.Equal(2, birth.NominalAgeAt(Create(2020, 1, 25, 0, 0, 0, shanghai)))
+	as.Equal(3, birth.NominalAgeAt(Create(2021, 2, 12, 0, 0, 0, shanghai)))
+	as.Equal(1, birth.NominalAgeAt(birth))
+}
@@ -0,0 +1,68 @@
+package carbon
+
+import (
+	"sort"
+	"time"
+)
+
+// weekStartOf 返回可选参数 weekStart，默认为星期一
+func weekStartOf(weekStart []time.Weekday) time.Weekday {
+	if len(weekStart) > 0 {
+		return weekStart[0]
+	}
+	return time.Monday
+}
+
+// Bucket 返回 c 所在的 n 个 unit 的时间桶的开始，可作为分组的键，如 Bucket(c, Minute, 5) 返回所在 5 分钟的开始。
+// 按 c 的时区计算，对齐方式与 Floor 相同，周默认从星期一开始，可用 weekStart 指定
+func Bucket(c *Carbon, unit Unit, n int, weekStart ...time.Weekday) *Carbon {
+	if n < 1 {
+		n = 1
+	}
+	return CreateFromGo(c.floor(unit, n, weekStartOf(weekStart)))
+}
+
+// Group GroupBy、FillGaps 返回的分组，Start 为时间桶的开始
+type Group struct {
+	Start *Carbon
+	Items []interface{}
+}
+
+// GroupBy 按 keyFn 返回的时间将 items 分到每个 unit 的时间桶，分组按时间排序，组内保持原顺序，
+// 周默认从星期一开始，可用 weekStart 指定
+func GroupBy(items []interface{}, keyFn func(item interface{}) *Carbon, unit Unit, weekStart ...time.Weekday) []*Group {
+	ws := weekStartOf(weekStart)
+	index := make(map[instantKey]*Group)
+	var groups []*Group
+	for _, item := range items {
+		start := keyFn(item).floor(unit, 1, ws)
+		g, ok := index[keyOf(start)]
+		if !ok {
+			g = &Group{Start: CreateFromGo(start)}
+			index[keyOf(start)] = g
+			groups = append(groups, g)
+		}
+		g.Items = append(g.Items, item)
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Start.time.Before(groups[j].Start.time) })
+	return groups
+}
+
+// FillGaps 返回 [start, end) 内每个 unit 的分组，existing 中没有的时间桶补为空分组，existing 可直接使用 GroupBy 的结果。
+// 时间桶按 start 的时区计算，夏令时当天按实际的小时数，existing 中不在范围内的分组会被忽略
+func FillGaps(start, end *Carbon, unit Unit, existing []*Group, weekStart ...time.Weekday) []*Group {
+	ws := weekStartOf(weekStart)
+	index := make(map[instantKey]*Group)
+	for _, g := range existing {
+		index[keyOf(g.Start.time)] = g
+	}
+	var result []*Group
+	for t := start.floor(unit, 1, ws); t.Before(end.time); t = CreateFromGo(t.Add(time.Nanosecond)).ceil(unit, 1, ws) {
+		g, ok := index[keyOf(t)]
+		if !ok {
+			g = &Group{Start: CreateFromGo(t)}
+		}
+		result = append(result, g)
+	}
+	return result
+}
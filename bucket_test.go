@@ -0,0 +1,108 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// point 测试用的数据点
+type point struct {
+	at    *Carbon
+	value int
+}
+
+// groupSummary 返回分组开始时间及每组的值
+func groupSummary(groups []*Group) ([]string, [][]int) {
+	var starts []string
+	var values [][]int
+	for _, g := range groups {
+		starts = append(starts, g.Start.ToDateTimeString())
+		var items []int
+		for _, item := range g.Items {
+			items = append(items, item.(point).value)
+		}
+		values = append(values, items)
+	}
+	return starts, values
+}
+
+func TestBucket(t *testing.T) {
+	as := assert.New(t)
+	c := CreateFromGo(time.Date(2019, 4, 12, 9, 38, 27, 0, time.UTC))
+	as.Equal("2019-04-12 09:35:00", Bucket(c, Minute, 5).ToDateTimeString())
+	as.Equal("2019-04-12 09:00:00", Bucket(c, Hour, 0).ToDateTimeString())
+	as.Equal("2019-04-12 00:00:00", Bucket(c, Day, 1).ToDateTimeString())
+	// 2019-04-12 为星期五
+	as.Equal("2019-04-08 00:00:00", Bucket(c, Week, 1).ToDateTimeString())
+	as.Equal("2019-04-07 00:00:00", Bucket(c, Week, 1, time.Sunday).ToDateTimeString())
+	as.Equal("2019-04-06 00:00:00", Bucket(c, Week, 1, time.Saturday).ToDateTimeString())
+	as.Equal("2019-04-12 00:00:00", Bucket(c, Week, 1, time.Friday).ToDateTimeString())
+	as.Equal("2019-04-01 00:00:00", Bucket(c, Month, 1).ToDateTimeString())
+
+	// 按 c 的时区计算
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	late := CreateFromGo(time.Date(2019, 4, 12, 20, 0, 0, 0, time.UTC))
+	as.Equal("2019-04-12 00:00:00", Bucket(late, Day, 1).ToDateTimeString())
+	as.Equal("2019-04-13 00:00:00 CST", Bucket(CreateFromGo(late.time.In(shanghai)), Day, 1).Format("2006-01-02 15:04:05 MST"))
+}
+
+func TestGroupBy(t *testing.T) {
+	as := assert.New(t)
+	items := []interface{}{
+		point{at(10, 15), 1},
+		point{at(9, 5), 2},
+		point{at(10, 59), 3},
+		point{at(13, 0), 4},
+		point{at(9, 55), 5},
+	}
+	key := func(item interface{}) *Carbon { return item.(point).at }
+	starts, values := groupSummary(GroupBy(items, key, Hour))
+	as.Equal([]string{"2019-04-12 09:00:00", "2019-04-12 10:00:00", "2019-04-12 13:00:00"}, starts)
+	as.Equal([][]int{{2, 5}, {1, 3}, {4}}, values)
+
+	starts, values = groupSummary(GroupBy(items, key, Week, time.Sunday))
+	as.Equal([]string{"2019-04-07 00:00:00"}, starts)
+	as.Equal([][]int{{1, 2, 3, 4, 5}}, values)
+	as.Empty(GroupBy(nil, key, Day))
+}
+
+func TestFillGaps(t *testing.T) {
+	as := assert.New(t)
+	day := func(d int) *Carbon { return CreateFromGo(time.Date(2019, 4, d, 12, 0, 0, 0, time.UTC)) }
+	items := []interface{}{point{day(11), 1}, point{day(13), 2}, point{day(13), 3}}
+	existing := GroupBy(items, func(item interface{}) *Carbon { return item.(point).at }, Day)
+
+	starts, values := groupSummary(FillGaps(day(10), day(14), Day, existing))
+	as.Equal([]string{
+		"2019-04-10 00:00:00",
+		"2019-04-11 00:00:00",
+		"2019-04-12 00:00:00",
+		"2019-04-13 00:00:00",
+		"2019-04-14 00:00:00",
+	}, starts)
+	as.Equal([][]int{nil, {1}, nil, {2, 3}, nil}, values)
+	// 结束时间在桶的开始时不包含该桶
+	as.Equal(4, len(FillGaps(day(10), Create(2019, 4, 14, 0, 0, 0, time.UTC), Day, existing)))
+	as.Equal(2, len(FillGaps(day(12), day(13), Day, existing)))
+	as.Empty(FillGaps(day(14), day(10), Day, existing))
+
+	starts, _ = groupSummary(FillGaps(day(1), day(30), Week, nil, time.Sunday))
+	as.Equal([]string{
+		"2019-03-31 00:00:00",
+		"2019-04-07 00:00:00",
+		"2019-04-14 00:00:00",
+		"2019-04-21 00:00:00",
+		"2019-04-28 00:00:00",
+	}, starts)
+	as.Equal(1, len(FillGaps(day(1), day(30), Month, nil)))
+
+	// 夏令时结束当天有 25 个小时
+	ny, _ := time.LoadLocation("America/New_York")
+	hours := FillGaps(Create(2019, 11, 3, 0, 0, 0, ny), Create(2019, 11, 4, 0, 0, 0, ny), Hour, nil)
+	as.Equal(25, len(hours))
+	as.Equal("01:00 EDT", hours[1].Start.Format("15:04 MST"))
+	as.Equal("01:00 EST", hours[2].Start.Format("15:04 MST"))
+	as.Equal(23, len(FillGaps(Create(2019, 3, 10, 0, 0, 0, ny), Create(2019, 3, 11, 0, 0, 0, ny), Hour, nil)))
+}
@@ -0,0 +1,393 @@
+package carbon
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calendar 历法，用于公历与其他历法之间的转换
+type Calendar interface {
+	// Name 返回历法名称，如 "japanese"
+	Name() string
+	// FromCarbon 返回 c 所在日期在该历法中的日期
+	FromCarbon(c *Carbon) *CalendarDate
+	// ToCarbon 返回该历法日期对应的公历日期，时间为 tz 时区的零时
+	ToCarbon(d *CalendarDate, tz *time.Location) (*Carbon, error)
+	// MonthName 返回该历法的月份名称
+	MonthName(month int) string
+	// Layout 返回该历法默认的格式，供 CalendarDate.String 使用
+	Layout() string
+}
+
+var (
+	// ROCCalendar 民国纪年，如 "民国108年4月12日"
+	ROCCalendar Calendar = rocCalendar{}
+	// JapaneseCalendar 日本年号纪年，如 "令和元年5月1日"
+	JapaneseCalendar Calendar = japaneseCalendar{}
+	// BuddhistCalendar 泰国佛历，如 "12 เมษายน พ.ศ. 2562"
+	BuddhistCalendar Calendar = buddhistCalendar{}
+	// HijriCalendar 伊斯兰历(表格历法)，如 "6 Sha'ban 1440 AH"
+	HijriCalendar Calendar = hijriCalendar{}
+	// PersianCalendar 伊朗历(太阳回历)，如 "23 Farvardin 1398 AP"
+	PersianCalendar Calendar = persianCalendar{}
+)
+
+// CalendarDate 其他历法中的日期
+type CalendarDate struct {
+	Calendar         Calendar
+	Era              string
+	Year, Month, Day int
+}
+
+// ToCalendar 返回当前日期在 cal 历法中的日期
+func (c *Carbon) ToCalendar(cal Calendar) *CalendarDate {
+	return cal.FromCarbon(c)
+}
+
+// CreateFromCalendar 从其他历法的日期中创建 Carbon，时间为零时
+func CreateFromCalendar(cal Calendar, era string, year, month, day int, tz *time.Location) (*Carbon, error) {
+	return cal.ToCarbon(&CalendarDate{Calendar: cal, Era: era, Year: year, Month: month, Day: day}, tz)
+}
+
+// ToCarbon 返回对应的公历日期
+func (d *CalendarDate) ToCarbon(tz *time.Location) (*Carbon, error) {
+	return d.Calendar.ToCarbon(d, tz)
+}
+
+// MonthName 返回月份名称
+func (d *CalendarDate) MonthName() string {
+	return d.Calendar.MonthName(d.Month)
+}
+
+// Format 按 layout 格式化日期，支持以下占位符，单引号内的文本原样输出，两个单引号输出一个单引号：
+//
+//	G    纪元，如 "令和"、"民国"、"AH"
+//	y    年，如 "108"
+//	yy   两位年，如 "08"
+//	Y    年，第一年输出为 "元"，如 "令和元年"
+//	M    月，如 "4"
+//	MM   两位月，如 "04"
+//	MMMM 月份名称
+//	d    日，如 "5"
+//	dd   两位日，如 "05"
+func (d *CalendarDate) Format(layout string) string {
+	var b strings.Builder
+	for i := 0; i < len(layout); {
+		ch := layout[i]
+		if ch == '\'' && i+1 < len(layout) && layout[i+1] == '\'' {
+			b.WriteByte('\'')
+			i += 2
+			continue
+		}
+		if ch == '\'' {
+			end := strings.IndexByte(layout[i+1:], '\'')
+			if end < 0 {
+				b.WriteString(layout[i+1:])
+				break
+			}
+			b.WriteString(layout[i+1 : i+1+end])
+			i += end + 2
+			continue
+		}
+		n := 1
+		for i+n < len(layout) && layout[i+n] == ch {
+			n++
+		}
+		switch ch {
+		case 'G':
+			b.WriteString(d.Era)
+		case 'y':
+			if n == 2 {
+				b.WriteString(pad(d.Year%100, 2))
+			} else {
+				b.WriteString(pad(d.Year, n))
+			}
+		case 'Y':
+			if d.Year == 1 {
+				b.WriteString("元")
+			} else {
+				b.WriteString(strconv.Itoa(d.Year))
+			}
+		case 'M':
+			if n >= 3 {
+				b.WriteString(d.MonthName())
+			} else {
+				b.WriteString(pad(d.Month, n))
+			}
+		case 'd':
+			b.WriteString(pad(d.Day, n))
+		default:
+			b.WriteString(layout[i : i+n])
+		}
+		i += n
+	}
+	return b.String()
+}
+
+// String 使用历法默认的格式返回日期
+func (d *CalendarDate) String() string {
+	return d.Format(d.Calendar.Layout())
+}
+
+// pad 返回至少 width 位、左侧补零的数字
+func pad(value, width int) string {
+	s := strconv.Itoa(value)
+	for len(s) < width {
+		s = "0" + s
+	}
+	return s
+}
+
+// jdnToDate 将儒略日数转换为公历日期
+func jdnToDate(jdn int, tz *time.Location) *Carbon {
+	return Create(1970, 1, 1+jdn-int(jdUnixEpoch+0.5), 0, 0, 0, tz)
+}
+
+// gregorianDate 返回公历日期，日期不存在时返回错误
+func gregorianDate(year, month, day int, tz *time.Location) (*Carbon, error) {
+	c := Create(year, month, day, 0, 0, 0, tz)
+	if c.time.Year() != year || int(c.time.Month()) != month || c.time.Day() != day {
+		return &Carbon{}, ErrCalendarDate
+	}
+	return c, nil
+}
+
+var chineseMonthNames = []string{"", "一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"}
+
+// monthName 返回 names 中第 month 个名称，越界时返回数字
+func monthName(names []string, month int) string {
+	if month < 1 || month >= len(names) {
+		return strconv.Itoa(month)
+	}
+	return names[month]
+}
+
+// rocCalendar 民国纪年，1912 年为民国元年，之前为民国前
+type rocCalendar struct{}
+
+func (rocCalendar) Name() string { return "roc" }
+
+func (rocCalendar) Layout() string { return "GY年M月d日" }
+
+func (rocCalendar) MonthName(month int) string { return monthName(chineseMonthNames, month) }
+
+func (cal rocCalendar) FromCarbon(c *Carbon) *CalendarDate {
+	d := &CalendarDate{Calendar: cal, Era: "民国", Year: c.time.Year() - 1911, Month: int(c.time.Month()), Day: c.time.Day()}
+	if d.Year < 1 {
+		d.Era = "民国前"
+		d.Year = 1 - d.Year
+	}
+	return d
+}
+
+func (rocCalendar) ToCarbon(d *CalendarDate, tz *time.Location) (*Carbon, error) {
+	if d.Year < 1 {
+		return &Carbon{}, ErrCalendarDate
+	}
+	switch d.Era {
+	case "民国", "":
+		return gregorianDate(d.Year+1911, d.Month, d.Day, tz)
+	case "民国前":
+		return gregorianDate(1912-d.Year, d.Month, d.Day, tz)
+	}
+	return &Carbon{}, ErrCalendarEra
+}
+
+// buddhistCalendar 泰国佛历，公历年份加 543
+type buddhistCalendar struct{}
+
+var thaiMonthNames = []string{"", "มกราคม", "กุมภาพันธ์", "มีนาคม", "เมษายน", "พฤษภาคม", "มิถุนายน",
+	"กรกฎาคม", "สิงหาคม", "กันยายน", "ตุลาคม", "พฤศจิกายน", "ธันวาคม"}
+
+func (buddhistCalendar) Name() string { return "buddhist" }
+
+func (buddhistCalendar) Layout() string { return "d MMMM G y" }
+
+func (buddhistCalendar) MonthName(month int) string { return monthName(thaiMonthNames, month) }
+
+func (cal buddhistCalendar) FromCarbon(c *Carbon) *CalendarDate {
+	return &CalendarDate{Calendar: cal, Era: "พ.ศ.", Year: c.time.Year() + 543, Month: int(c.time.Month()), Day: c.time.Day()}
+}
+
+func (buddhistCalendar) ToCarbon(d *CalendarDate, tz *time.Location) (*Carbon, error) {
+	return gregorianDate(d.Year-543, d.Month, d.Day, tz)
+}
+
+// japaneseEra 日本年号及其开始日期
+type japaneseEra struct {
+	name             string
+	year, month, day int
+}
+
+// japaneseEras 明治改历(明治6年)之后使用公历，之前的日期按明治元年为 1868 年推算
+var japaneseEras = []japaneseEra{
+	{"明治", 1868, 1, 1},
+	{"大正", 1912, 7, 30},
+	{"昭和", 1926, 12, 25},
+	{"平成", 1989, 1, 8},
+	{"令和", 2019, 5, 1},
+}
+
+// japaneseCalendar 日本年号纪年，明治之前的日期年号为空，年份为公历年份
+type japaneseCalendar struct{}
+
+func (japaneseCalendar) Name() string { return "japanese" }
+
+func (japaneseCalendar) Layout() string { return "GY年M月d日" }
+
+func (japaneseCalendar) MonthName(month int) string { return strconv.Itoa(month) + "月" }
+
+func (cal japaneseCalendar) FromCarbon(c *Carbon) *CalendarDate {
+	y, m, d := c.time.Date()
+	date := &CalendarDate{Calendar: cal, Year: y, Month: int(m), Day: d}
+	for i := len(japaneseEras) - 1; i >= 0; i-- {
+		era := japaneseEras[i]
+		if y > era.year || (y == era.year && (int(m) > era.month || (int(m) == era.month && d >= era.day))) {
+			date.Era = era.name
+			date.Year = y - era.year + 1
+			break
+		}
+	}
+	return date
+}
+
+func (cal japaneseCalendar) ToCarbon(d *CalendarDate, tz *time.Location) (*Carbon, error) {
+	if d.Era == "" {
+		c, err := gregorianDate(d.Year, d.Month, d.Day, tz)
+		if err != nil {
+			return c, err
+		}
+		if cal.FromCarbon(c).Era != "" {
+			return &Carbon{}, ErrCalendarDate
+		}
+		return c, nil
+	}
+	for _, era := range japaneseEras {
+		if era.name != d.Era {
+			continue
+		}
+		c, err := gregorianDate(era.year+d.Year-1, d.Month, d.Day, tz)
+		if err != nil {
+			return c, err
+		}
+		// 日期必须落在该年号的范围内
+		if d.Year < 1 || cal.FromCarbon(c).Era != era.name {
+			return &Carbon{}, ErrCalendarDate
+		}
+		return c, nil
+	}
+	return &Carbon{}, ErrCalendarEra
+}
+
+// hijriCalendar 伊斯兰历表格历法，以公元 622 年 7 月 16 日(儒略历)为纪元，
+// 30 年中第 2、5、7、10、13、16、18、21、24、26、29 年为闰年
+type hijriCalendar struct{}
+
+// hijriEpoch 伊斯兰历纪元的儒略日数
+const hijriEpoch = 1948440
+
+var hijriMonthNames = []string{"", "Muharram", "Safar", "Rabi' al-awwal", "Rabi' al-thani", "Jumada al-awwal",
+	"Jumada al-thani", "Rajab", "Sha'ban", "Ramadan", "Shawwal", "Dhu al-Qi'dah", "Dhu al-Hijjah"}
+
+func (hijriCalendar) Name() string { return "hijri" }
+
+func (hijriCalendar) Layout() string { return "d MMMM y G" }
+
+func (hijriCalendar) MonthName(month int) string { return monthName(hijriMonthNames, month) }
+
+// hijriToJDN 返回伊斯兰历日期对应的儒略日数
+func hijriToJDN(year, month, day int) int {
+	return day + (59*(month-1)+1)/2 + (year-1)*354 + floorDiv(3+11*year, 30) + hijriEpoch - 1
+}
+
+// hijriMonthDays 返回伊斯兰历 year 年 month 月的天数
+func hijriMonthDays(year, month int) int {
+	if month == 12 {
+		return hijriToJDN(year+1, 1, 1) - hijriToJDN(year, 12, 1)
+	}
+	return 30 - (month+1)%2
+}
+
+func (cal hijriCalendar) FromCarbon(c *Carbon) *CalendarDate {
+	jdn := c.julianDayNumber()
+	year := floorDiv(30*(jdn-hijriEpoch)+10646, 10631)
+	month := int(math.Ceil(float64(jdn-29-hijriToJDN(year, 1, 1))/29.5)) + 1
+	if month > 12 {
+		month = 12
+	}
+	day := jdn - hijriToJDN(year, month, 1) + 1
+	return &CalendarDate{Calendar: cal, Era: "AH", Year: year, Month: month, Day: day}
+}
+
+func (hijriCalendar) ToCarbon(d *CalendarDate, tz *time.Location) (*Carbon, error) {
+	if d.Month < 1 || d.Month > 12 || d.Day < 1 || d.Day > hijriMonthDays(d.Year, d.Month) {
+		return &Carbon{}, ErrCalendarDate
+	}
+	return jdnToDate(hijriToJDN(d.Year, d.Month, d.Day), tz), nil
+}
+
+// persianCalendar 伊朗历(太阳回历)，以德黑兰(UTC+3:30)正午前交春分的那天为新年
+type persianCalendar struct{}
+
+var persianMonthNames = []string{"", "Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar",
+	"Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand"}
+
+// tehran 伊朗标准时间
+var tehran = time.FixedZone("IRST", 3*3600+1800)
+
+func (persianCalendar) Name() string { return "persian" }
+
+func (persianCalendar) Layout() string { return "d MMMM y G" }
+
+func (persianCalendar) MonthName(month int) string { return monthName(persianMonthNames, month) }
+
+// nowruz 返回伊朗历 year 年元旦的儒略日数
+func nowruz(year int) int {
+	equinox := solarTermOf(year+621, 5, tehran).Time
+	jdn := equinox.julianDayNumber()
+	if equinox.time.Hour() >= 12 {
+		jdn++
+	}
+	return jdn
+}
+
+// persianDayOfYear 返回伊朗历 month 月 day 日是一年中的第几天(从 0 开始)
+func persianDayOfYear(month, day int) int {
+	if month <= 7 {
+		return (month-1)*31 + day - 1
+	}
+	return 6*31 + (month-7)*30 + day - 1
+}
+
+func (cal persianCalendar) FromCarbon(c *Carbon) *CalendarDate {
+	jdn := c.julianDayNumber()
+	year := c.time.Year() - 621
+	start := nowruz(year)
+	if jdn < start {
+		year--
+		start = nowruz(year)
+	}
+	days := jdn - start
+	date := &CalendarDate{Calendar: cal, Era: "AP", Year: year}
+	if days < 6*31 {
+		date.Month, date.Day = days/31+1, days%31+1
+	} else {
+		days -= 6 * 31
+		date.Month, date.Day = days/30+7, days%30+1
+	}
+	return date
+}
+
+func (persianCalendar) ToCarbon(d *CalendarDate, tz *time.Location) (*Carbon, error) {
+	if d.Month < 1 || d.Month > 12 || d.Day < 1 || d.Day > 31 || (d.Month > 6 && d.Day > 30) {
+		return &Carbon{}, ErrCalendarDate
+	}
+	start := nowruz(d.Year)
+	jdn := start + persianDayOfYear(d.Month, d.Day)
+	if jdn >= nowruz(d.Year+1) {
+		return &Carbon{}, ErrCalendarDate
+	}
+	return jdnToDate(jdn, tz), nil
+}
@@ -0,0 +1,90 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarbon_ToCalendar(t *testing.T) {
+	as := assert.New(t)
+	tests := []struct {
+		cal                 Calendar
+		year, month, day    int
+		era                 string
+		cYear, cMonth, cDay int
+		str                 string
+	}{
+		{ROCCalendar, 2019, 4, 12, "民国", 108, 4, 12, "民国108年4月12日"},
+		{ROCCalendar, 1912, 1, 1, "民国", 1, 1, 1, "民国元年1月1日"},
+		{ROCCalendar, 1911, 10, 10, "民国前", 1, 10, 10, "民国前元年10月10日"},
+		{JapaneseCalendar, 2019, 4, 30, "平成", 31, 4, 30, "平成31年4月30日"},
+		{JapaneseCalendar, 2019, 5, 1, "令和", 1, 5, 1, "令和元年5月1日"},
+		{JapaneseCalendar, 1989, 1, 7, "昭和", 64, 1, 7, "昭和64年1月7日"},
+		{JapaneseCalendar, 1912, 7, 30, "大正", 1, 7, 30, "大正元年7月30日"},
+		{JapaneseCalendar, 1867, 12, 31, "", 1867, 12, 31, "1867年12月31日"},
+		{BuddhistCalendar, 2019, 4, 12, "พ.ศ.", 2562, 4, 12, "12 เมษายน พ.ศ. 2562"},
+		{HijriCalendar, 2019, 4, 12, "AH", 1440, 8, 6, "6 Sha'ban 1440 AH"},
+		{HijriCalendar, 2019, 5, 6, "AH", 1440, 9, 1, "1 Ramadan 1440 AH"},
+		{HijriCalendar, 2024, 3, 11, "AH", 1445, 9, 1, "1 Ramadan 1445 AH"},
+		{PersianCalendar, 2019, 3, 21, "AP", 1398, 1, 1, "1 Farvardin 1398 AP"},
+		{PersianCalendar, 2019, 4, 12, "AP", 1398, 1, 23, "23 Farvardin 1398 AP"},
+		{PersianCalendar, 2024, 3, 20, "AP", 1403, 1, 1, "1 Farvardin 1403 AP"},
+		{PersianCalendar, 2025, 3, 20, "AP", 1403, 12, 30, "30 Esfand 1403 AP"},
+		{PersianCalendar, 2025, 3, 21, "AP", 1404, 1, 1, "1 Farvardin 1404 AP"},
+	}
+	for _, tt := range tests {
+		c := Create(tt.year, tt.month, tt.day, 15, 4, 5, time.Local)
+		d := c.ToCalendar(tt.cal)
+		as.Equal(tt.era, d.Era, tt.str)
+		as.Equal([]int{tt.cYear, tt.cMonth, tt.cDay}, []int{d.Year, d.Month, d.Day}, tt.str)
+		as.Equal(tt.str, d.String())
+
+		back, err := CreateFromCalendar(tt.cal, tt.era, tt.cYear, tt.cMonth, tt.cDay, time.Local)
+		as.NoError(err, tt.str)
+		as.Equal(c.ToDateString(), back.ToDateString(), tt.str)
+	}
+}
+
+func TestCalendar_RoundTrip(t *testing.T) {
+	as := assert.New(t)
+	for _, cal := range []Calendar{ROCCalendar, JapaneseCalendar, BuddhistCalendar, HijriCalendar, PersianCalendar} {
+		c := Create(1990, 1, 1, 0, 0, 0, time.UTC)
+		for i := 0; i < 365*12; i += 3 {
+			d := c.ToCalendar(cal)
+			back, err := d.ToCarbon(time.UTC)
+			if !as.NoError(err, "%s %s", cal.Name(), d) || !as.Equal(c.ToDateString(), back.ToDateString(), cal.Name()) {
+				break
+			}
+			c = CreateFromGo(c.time.AddDate(0, 0, 3))
+		}
+	}
+}
+
+func TestCreateFromCalendar(t *testing.T) {
+	as := assert.New(t)
+	_, err := CreateFromCalendar(JapaneseCalendar, "令和", 1, 4, 30, time.Local)
+	as.Equal(ErrCalendarDate, err)
+	_, err = CreateFromCalendar(JapaneseCalendar, "平成", 32, 1, 1, time.Local)
+	as.Equal(ErrCalendarDate, err)
+	_, err = CreateFromCalendar(JapaneseCalendar, "天平", 1, 1, 1, time.Local)
+	as.Equal(ErrCalendarEra, err)
+	// 没有年号时只能是明治之前的日期
+	_, err = CreateFromCalendar(JapaneseCalendar, "", 1868, 1, 1, time.Local)
+	as.Equal(ErrCalendarDate, err)
+	_, err = CreateFromCalendar(HijriCalendar, "AH", 1440, 2, 30, time.Local)
+	as.Equal(ErrCalendarDate, err)
+	_, err = CreateFromCalendar(PersianCalendar, "AP", 1402, 12, 30, time.Local)
+	as.Equal(ErrCalendarDate, err)
+	_, err = CreateFromCalendar(BuddhistCalendar, "", 2562, 2, 29, time.Local)
+	as.Equal(ErrCalendarDate, err)
+}
+
+func TestCalendarDate_Format(t *testing.T) {
+	as := assert.New(t)
+	d := Create(2019, 5, 1, 0, 0, 0, time.Local).ToCalendar(JapaneseCalendar)
+	as.Equal("令和1年05月01日", d.Format("Gy年MM月dd日"))
+	as.Equal("R01.05.01 '令和'", d.Format("'R'yy.MM.dd ''G''"))
+	as.Equal("5月", d.Format("MMMM"))
+}
@@ -0,0 +1,126 @@
+package carbon
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// CalendarFormats Calendar 使用的模板，模板中的 {time}、{date}、{weekday}
+// 分别替换为按 TimeLayout、DateLayout 格式化的时间、日期及星期名称
+type CalendarFormats struct {
+	// SameDay 今天，如 "Today at {time}"
+	SameDay string
+	// NextDay 明天
+	NextDay string
+	// LastDay 昨天
+	LastDay string
+	// NextWeek 之后的七天内
+	NextWeek string
+	// LastWeek 之前的七天内
+	LastWeek string
+	// ThisWeek 七天内且与参照时间在同一周，为空时使用 NextWeek、LastWeek
+	ThisWeek string
+	// SameElse 其他日期，如 "{date}"
+	SameElse string
+	// TimeLayout 时间格式，如 "3:04 PM"
+	TimeLayout string
+	// DateLayout 日期格式，如 "01/02/2006"
+	DateLayout string
+	// Weekdays 星期名称，从星期日开始
+	Weekdays [7]string
+	// WeekStart 每周的第一天
+	WeekStart time.Weekday
+}
+
+// CalendarOptions Calendar 的选项
+type CalendarOptions struct {
+	// Locale 语言，如 "en"、"zh-CN"，默认为 "en"
+	Locale string
+	// Formats 自定义模板，不为空时忽略 Locale
+	Formats *CalendarFormats
+}
+
+var (
+	calendarFormatsMu sync.RWMutex
+	calendarFormats   = map[string]*CalendarFormats{}
+)
+
+// RegisterCalendarFormats 注册或替换 locale 语言的 Calendar 模板，优先于 RegisterLocale 注册的语言数据
+func RegisterCalendarFormats(locale string, formats *CalendarFormats) {
+	calendarFormatsMu.Lock()
+	defer calendarFormatsMu.Unlock()
+	calendarFormats[locale] = formats
+}
+
+// calendarFormatsOf 返回 locale 语言的模板，按回退链查找
+func calendarFormatsOf(locale string) *CalendarFormats {
+	for _, name := range LocaleFallbacks(locale) {
+		calendarFormatsMu.RLock()
+		f, ok := calendarFormats[name]
+		calendarFormatsMu.RUnlock()
+		if ok {
+			return f
+		}
+	}
+	return compiledLocaleOf(locale).calendar
+}
+
+// daysBetween 返回 a 到 b 相差的自然日数
+func daysBetween(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	d := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC).Sub(time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC))
+	return int(d.Hours() / 24)
+}
+
+// Calendar 返回相对于 reference 的日历式描述，如 "Today at 3:04 PM"、"昨天 15:04"、"上周一 09:00"，
+// 超过一周时返回日期。reference 为 nil 时与现在比较，按 reference 所在时区计算自然日
+func (c *Carbon) Calendar(reference *Carbon, opts *CalendarOptions) string {
+	if reference == nil {
+		reference = Now()
+	}
+	if opts == nil {
+		opts = &CalendarOptions{}
+	}
+	f := opts.Formats
+	if f == nil {
+		f = calendarFormatsOf(opts.Locale)
+	}
+
+	ref := reference.time
+	t := c.time.In(ref.Location())
+	days := daysBetween(ref, t)
+
+	var tpl string
+	switch {
+	case days < -6 || days > 6:
+		tpl = f.SameElse
+	case days == 0:
+		tpl = f.SameDay
+	case days == 1:
+		tpl = f.NextDay
+	case days == -1:
+		tpl = f.LastDay
+	case f.ThisWeek != "" && sameWeek(ref, t, f.WeekStart):
+		tpl = f.ThisWeek
+	case days > 0:
+		tpl = f.NextWeek
+	default:
+		tpl = f.LastWeek
+	}
+
+	return strings.NewReplacer(
+		"{time}", t.Format(f.TimeLayout),
+		"{date}", t.Format(f.DateLayout),
+		"{weekday}", f.Weekdays[t.Weekday()],
+	).Replace(tpl)
+}
+
+// sameWeek 判断 a、b 是否在以 weekStart 开始的同一周
+func sameWeek(a, b time.Time, weekStart time.Weekday) bool {
+	offset := func(t time.Time) int {
+		return (int(t.Weekday()) - int(weekStart) + 7) % 7
+	}
+	return daysBetween(a, b) == offset(b)-offset(a)
+}
@@ -0,0 +1,60 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarbon_Calendar(t *testing.T) {
+	as := assert.New(t)
+	shanghai := time.FixedZone("CST", 8*3600)
+	// 2019-04-12 是星期五
+	ref := Create(2019, 4, 12, 10, 0, 0, shanghai)
+	tests := []struct {
+		date   *Carbon
+		en, zh string
+	}{
+		{Create(2019, 4, 12, 15, 4, 0, shanghai), "Today at 3:04 PM", "今天 15:04"},
+		{Create(2019, 4, 11, 23, 59, 0, shanghai), "Yesterday at 11:59 PM", "昨天 23:59"},
+		{Create(2019, 4, 13, 0, 1, 0, shanghai), "Tomorrow at 12:01 AM", "明天 00:01"},
+		{Create(2019, 4, 8, 9, 0, 0, shanghai), "Last Monday at 9:00 AM", "本周一 09:00"},
+		{Create(2019, 4, 7, 9, 0, 0, shanghai), "Last Sunday at 9:00 AM", "上周日 09:00"},
+		{Create(2019, 4, 6, 9, 0, 0, shanghai), "Last Saturday at 9:00 AM", "上周六 09:00"},
+		{Create(2019, 4, 14, 9, 0, 0, shanghai), "Sunday at 9:00 AM", "本周日 09:00"},
+		{Create(2019, 4, 15, 9, 0, 0, shanghai), "Monday at 9:00 AM", "下周一 09:00"},
+		{Create(2019, 4, 5, 9, 0, 0, shanghai), "04/05/2019", "2019/04/05"},
+		{Create(2019, 4, 19, 9, 0, 0, shanghai), "04/19/2019", "2019/04/19"},
+		// 按参照时间的时区计算自然日
+		{Create(2019, 4, 12, 17, 0, 0, time.UTC), "Tomorrow at 1:00 AM", "明天 01:00"},
+	}
+	for _, tt := range tests {
+		as.Equal(tt.en, tt.date.Calendar(ref, nil))
+		as.Equal(tt.zh, tt.date.Calendar(ref, &CalendarOptions{Locale: "zh-CN"}))
+	}
+
+	as.Equal("Today at 3:04 PM", Create(2019, 4, 12, 15, 4, 0, shanghai).Calendar(ref, &CalendarOptions{Locale: "xx"}))
+	as.Contains(Now().Calendar(nil, nil), "Today at ")
+}
+
+func TestRegisterCalendarFormats(t *testing.T) {
+	as := assert.New(t)
+	f := *calendarFormatsOf("en")
+	f.SameDay = "Heute um {time}"
+	f.TimeLayout = "15:04"
+	RegisterCalendarFormats("test", &f)
+
+	ref := Create(2019, 4, 12, 10, 0, 0, time.UTC)
+	as.Equal("Heute um 15:04", Create(2019, 4, 12, 15, 4, 0, time.UTC).Calendar(ref, &CalendarOptions{Locale: "test"}))
+	as.Equal("Heute um 15:04", Create(2019, 4, 12, 15, 4, 0, time.UTC).Calendar(ref, &CalendarOptions{Formats: &f}))
+}
+
+func TestCarbon_IsTomorrow(t *testing.T) {
+	as := assert.New(t)
+	as.True(Tomorrow().IsTomorrow())
+	as.False(Now().IsTomorrow())
+	// 不同月份的同一天不是今天
+	as.False(CreateFromGo(time.Now().AddDate(0, -1, 0)).IsToday())
+	as.False(CreateFromGo(time.Now().AddDate(0, -1, -1)).IsYesterday())
+}
@@ -1,9 +1,8 @@
 package carbon
 
 import (
-	"bytes"
 	"errors"
-	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -33,6 +32,8 @@ const (
 	Nanosecond
 	// Week 周
 	Week
+	// QuarterUnit 季度，Quarter 已是季度类型
+	QuarterUnit
 )
 const (
 	// January 一月
@@ -63,19 +64,19 @@ const (
 
 const (
 	// Sunday 周日
-	Sunday = "Sunday"
+	Sunday = time.Sunday
 	// Monday 周一
-	Monday = "Monday"
+	Monday = time.Monday
 	// Tuesday 周二
-	Tuesday = "Tuesday"
+	Tuesday = time.Tuesday
 	// Wednesday 三
-	Wednesday = "Wednesday"
+	Wednesday = time.Wednesday
 	// Thursday 四
-	Thursday = "Thursday"
+	Thursday = time.Thursday
 	// Friday 五
-	Friday = "Friday"
+	Friday = time.Friday
 	// Saturday 六
-	Saturday = "Saturday"
+	Saturday = time.Saturday
 )
 
 // Now 获取现在时刻时间
@@ -287,13 +288,61 @@ func CreateFromTimestamp(value int64) *Carbon {
 	}
 }
 
-// CreateFromTimestampString 同 CreateFromTimestamp 类似，只不过参数为时间戳字符串，并返回解析错误
+// CreateFromTimestampMilli 从毫秒级时间戳中解析 Carbon
+func CreateFromTimestampMilli(value int64) *Carbon {
+	return CreateFromGo(time.Unix(value/1e3, value%1e3*1e6))
+}
+
+// CreateFromTimestampMicro 从微秒级时间戳中解析 Carbon
+func CreateFromTimestampMicro(value int64) *Carbon {
+	return CreateFromGo(time.Unix(value/1e6, value%1e6*1e3))
+}
+
+// CreateFromTimestampNano 从纳秒级时间戳中解析 Carbon
+func CreateFromTimestampNano(value int64) *Carbon {
+	return CreateFromGo(time.Unix(0, value))
+}
+
+// CreateFromTimestampFloat 从带小数的秒级时间戳中解析 Carbon，如 1554307200.123，
+// 受浮点数精度限制只精确到微秒左右，需要更高精度时使用 CreateFromTimestampString
+func CreateFromTimestampFloat(value float64) *Carbon {
+	sec := math.Floor(value)
+	return CreateFromGo(time.Unix(int64(sec), int64(math.Round((value-sec)*1e9))))
+}
+
+// CreateFromTimestampString 同 CreateFromTimestamp 类似，只不过参数为时间戳字符串，并返回解析错误。
+// 带小数时如 "1554307200.123" 视为秒级时间戳，否则按位数识别精度：
+// 10 位及以下为秒，11 到 13 位为毫秒，14 到 16 位为微秒，17 到 19 位为纳秒
 func CreateFromTimestampString(value string) (*Carbon, error) {
-	i, err := strconv.ParseInt(value, 10, 64)
+	if i := strings.IndexByte(value, '.'); i >= 0 {
+		return createFromTimestampFraction(value[:i], value[i+1:])
+	}
+	ts, err := strconv.ParseInt(value, 10, 64)
 	if err != nil {
 		return &Carbon{}, ErrTimestampParse
 	}
-	return CreateFromTimestamp(i), nil
+	switch digits := len(strings.TrimLeft(value, "+-")); {
+	case digits <= 10:
+		return CreateFromTimestamp(ts), nil
+	case digits <= 13:
+		return CreateFromTimestampMilli(ts), nil
+	case digits <= 16:
+		return CreateFromTimestampMicro(ts), nil
+	}
+	return CreateFromTimestampNano(ts), nil
+}
+
+// createFromTimestampFraction 从整数部分及小数部分的字符串解析秒级时间戳，小数部分最多 9 位
+func createFromTimestampFraction(integer, fraction string) (*Carbon, error) {
+	sec, err := strconv.ParseInt(integer, 10, 64)
+	if err != nil || fraction == "" || len(fraction) > 9 || strings.Trim(fraction, "0123456789") != "" {
+		return &Carbon{}, ErrTimestampParse
+	}
+	nsec, _ := strconv.ParseInt(fraction+strings.Repeat("0", 9-len(fraction)), 10, 64)
+	if strings.HasPrefix(integer, "-") {
+		nsec = -nsec
+	}
+	return CreateFromGo(time.Unix(sec, nsec)), nil
 }
 
 // Carbon 处理时间
@@ -317,6 +366,21 @@ func (c *Carbon) Timestamp() int64 {
 	return c.time.Unix()
 }
 
+// TimestampMilli 获取毫秒级时间戳
+func (c *Carbon) TimestampMilli() int64 {
+	return c.time.Unix()*1e3 + int64(c.time.Nanosecond())/1e6
+}
+
+// TimestampMicro 获取微秒级时间戳
+func (c *Carbon) TimestampMicro() int64 {
+	return c.time.Unix()*1e6 + int64(c.time.Nanosecond())/1e3
+}
+
+// TimestampNano 获取纳秒级时间戳，超出 1678 年到 2262 年的范围时结果无意义
+func (c *Carbon) TimestampNano() int64 {
+	return c.time.UnixNano()
+}
+
 // IsLeapYear 判断是不是闰年
 func (c *Carbon) IsLeapYear() bool {
 	return (c.Year%100 != 0 && c.Year%4 == 0) || (c.Year%400 == 0)
@@ -359,26 +423,21 @@ func (c *Carbon) addValToUnit(unit Unit, value int) error {
 		c.Month += time.Month(value)
 		c.time = c.time.Add(time.Duration(value) * time.Hour * 24 * time.Duration(c.CountDayForMonth()))
 	case Day:
-		c.Day += value
-		c.time = c.time.Add(time.Duration(value) * time.Hour * 24)
+		*c = *CreateFromGo(c.time.Add(time.Duration(value) * time.Hour * 24))
 	case Hour:
-		c.Hour += value
-		c.time = c.time.Add(time.Duration(value) * time.Hour)
+		*c = *CreateFromGo(c.time.Add(time.Duration(value) * time.Hour))
 	case Minute:
-		c.Minute += value
-		c.time = c.time.Add(time.Duration(value) * time.Minute)
+		*c = *CreateFromGo(c.time.Add(time.Duration(value) * time.Minute))
 	case Second:
-		c.Second += value
-		c.time = c.time.Add(time.Duration(value) * time.Second)
+		*c = *CreateFromGo(c.time.Add(time.Duration(value) * time.Second))
 	case Millisecond:
-		c.Millisecond += value
-		c.time = c.time.Add(time.Duration(value) * time.Nanosecond * 1000)
+		*c = *CreateFromGo(c.time.Add(time.Duration(value) * time.Millisecond))
+	case Microsecond:
+		*c = *CreateFromGo(c.time.Add(time.Duration(value) * time.Microsecond))
 	case Nanosecond:
-		c.Nanosecond += value
-		c.time = c.time.Add(time.Duration(value) * time.Nanosecond)
+		*c = *CreateFromGo(c.time.Add(time.Duration(value) * time.Nanosecond))
 	case Week:
-		c.Week += time.Weekday(value)
-		c.time = c.time.Add(time.Duration(value) * 7 * 24 * time.Hour)
+		*c = *CreateFromGo(c.time.Add(time.Duration(value) * 7 * 24 * time.Hour))
 	default:
 		return errors.New("添加类型错误")
 	}
@@ -400,26 +459,21 @@ func (c *Carbon) subValToUnit(unit Unit, value int) error {
 		c.Month -= time.Month(value)
 		c.time = c.time.Add(-time.Duration(value) * time.Hour * 24 * time.Duration(c.CountDayForMonth()))
 	case Day:
-		c.Day -= value
-		c.time = c.time.Add(-time.Duration(value) * time.Hour * 24)
+		*c = *CreateFromGo(c.time.Add(-time.Duration(value) * time.Hour * 24))
 	case Hour:
-		c.Hour -= value
-		c.time = c.time.Add(-time.Duration(value) * time.Hour)
+		*c = *CreateFromGo(c.time.Add(-time.Duration(value) * time.Hour))
 	case Minute:
-		c.Minute -= value
-		c.time = c.time.Add(-time.Duration(value) * time.Minute)
+		*c = *CreateFromGo(c.time.Add(-time.Duration(value) * time.Minute))
 	case Second:
-		c.Second -= value
-		c.time = c.time.Add(-time.Duration(value) * time.Second)
+		*c = *CreateFromGo(c.time.Add(-time.Duration(value) * time.Second))
 	case Millisecond:
-		c.Millisecond -= value
-		c.time = c.time.Add(-time.Duration(value) * time.Nanosecond * 1000)
+		*c = *CreateFromGo(c.time.Add(-time.Duration(value) * time.Millisecond))
+	case Microsecond:
+		*c = *CreateFromGo(c.time.Add(-time.Duration(value) * time.Microsecond))
 	case Nanosecond:
-		c.Nanosecond -= value
-		c.time = c.time.Add(-time.Duration(value) * time.Nanosecond)
+		*c = *CreateFromGo(c.time.Add(-time.Duration(value) * time.Nanosecond))
 	case Week:
-		c.Week -= time.Weekday(value)
-		c.time = c.time.Add(-time.Duration(value) * 7 * 24 * time.Hour)
+		*c = *CreateFromGo(c.time.Add(-time.Duration(value) * 7 * 24 * time.Hour))
 	default:
 		return errors.New("添加类型错误")
 	}
@@ -554,38 +608,38 @@ func (c *Carbon) SubSeconds(value int) *Carbon {
 
 // IsSunday 判断是不是周日
 func (c *Carbon) IsSunday() bool {
-	return c.time.Weekday().String() == Sunday
+	return c.time.Weekday() == Sunday
 
 }
 
 // IsMonday 判断是不是周一
 func (c *Carbon) IsMonday() bool {
-	return c.time.Weekday().String() == Monday
+	return c.time.Weekday() == Monday
 }
 
 // IsTuesday 判断是不是周二
 func (c *Carbon) IsTuesday() bool {
-	return c.time.Weekday().String() == Tuesday
+	return c.time.Weekday() == Tuesday
 }
 
 // IsWednesday 判断是不是周三
 func (c *Carbon) IsWednesday() bool {
-	return c.time.Weekday().String() == Wednesday
+	return c.time.Weekday() == Wednesday
 }
 
 // IsThursday 判断是不是周四
 func (c *Carbon) IsThursday() bool {
-	return c.time.Weekday().String() == Thursday
+	return c.time.Weekday() == Thursday
 }
 
 // IsFriday 判断是不是周五
 func (c *Carbon) IsFriday() bool {
-	return c.time.Weekday().String() == Friday
+	return c.time.Weekday() == Friday
 }
 
 // IsSaturday 判断是不是周六
 func (c *Carbon) IsSaturday() bool {
-	return c.time.Weekday().String() == Saturday
+	return c.time.Weekday() == Saturday
 }
 
 // IsWeekend 判断是不是周末
@@ -736,13 +790,19 @@ func (c *Carbon) IsLastMonth() bool {
 // IsToday 判断是否是今天
 func IsToday(c *Carbon) bool { return c.IsToday() }
 func (c *Carbon) IsToday() bool {
-	return Now().Day == c.Day
+	return daysBetween(time.Now().In(c.time.Location()), c.time) == 0
 }
 
 // IsYesterday 判断是否是昨天
 func IsYesterday(c *Carbon) bool { return c.IsYesterday() }
 func (c *Carbon) IsYesterday() bool {
-	return Yesterday().Day == c.Day
+	return daysBetween(time.Now().In(c.time.Location()), c.time) == -1
+}
+
+// IsTomorrow 判断是否是明天
+func IsTomorrow(c *Carbon) bool { return c.IsTomorrow() }
+func (c *Carbon) IsTomorrow() bool {
+	return daysBetween(time.Now().In(c.time.Location()), c.time) == 1
 }
 
 // CurrentQuarter 返回当前季度
@@ -794,12 +854,23 @@ func (c *Carbon) ToTimeString() string {
 	return c.Format(layout)
 }
 
-// ToFormattedDateString 返回格式化可读性的日期
-func (c *Carbon) ToFormattedDateString() string {
+// ToFormattedDateString 返回格式化可读性的日期，如 "Apr 12,2019"，指定 locale 时使用该语言的格式，如 "2019年4月12日"
+func (c *Carbon) ToFormattedDateString(locale ...string) string {
+	if len(locale) > 0 {
+		return c.FormatLocale(dateFormatsOf(locale[0]).FormattedDate, locale[0])
+	}
 	layout := "Jan 02,2006"
 	return c.Format(layout)
 }
 
+// ToDayDateTimeString 返回带星期的日期时间，如 "Fri, Apr 12, 2019 3:04 PM"，指定 locale 时使用该语言的格式
+func (c *Carbon) ToDayDateTimeString(locale ...string) string {
+	if len(locale) > 0 {
+		return c.FormatLocale(dateFormatsOf(locale[0]).DayDateTime, locale[0])
+	}
+	return c.Format(DayDateTimeLayout)
+}
+
 // String as same as ToDateTimeString
 func (c *Carbon) String() string {
 	return c.ToDateTimeString()
@@ -891,6 +962,7 @@ func (c *Carbon) Before(u *Carbon) bool {
 	return c.time.Before(u.time)
 }
 
+// Deprecated: DiffForHumans 已按日历计算单位边界，以下常量不再使用
 const (
 	SecondMax = 60
 	MinuteMax = SecondMax * 60 // 360,0
@@ -901,87 +973,7 @@ const (
 	YearMax   = MonthsMax * 9999
 )
 
+// DiffForHumans 返回人类可读的时间差，如 "1 day ago"，other 为空时与现在比较
 func (c *Carbon) DiffForHumans(other ...*Carbon) string {
-	var o *Carbon
-	var val bytes.Buffer
-	var len = len(other)
-
-	if len > 0 {
-		o = other[0]
-	} else {
-		o = Now()
-	}
-	c1 := c.Timestamp()
-	o1 := o.Timestamp()
-	diff := o1 - c1
-	if diff < 0 {
-		diff = ^diff + 1
-	}
-	switch {
-	case diff < SecondMax:
-		n := diff
-		val.WriteString(fmt.Sprintf("%d ", diff))
-		val.WriteString("second")
-		if n > 1 {
-			val.WriteString("s")
-		}
-	case diff < MinuteMax:
-		n := diff / 60
-		val.WriteString(fmt.Sprintf("%d ", n))
-		val.WriteString("minute")
-		if n > 1 {
-			val.WriteString("s")
-		}
-	case diff < HourMax:
-		n := diff / 60 / 60
-		val.WriteString(fmt.Sprintf("%d ", diff/60/60))
-		val.WriteString("hour")
-		if n > 1 {
-			val.WriteString("s")
-		}
-	case diff < DayMax:
-		n := diff / 60 / 60 / 24
-		val.WriteString(fmt.Sprintf("%d ", n))
-		val.WriteString("day")
-		if n > 1 {
-			val.WriteString("s")
-		}
-	case diff < WeekMax:
-		n := diff / 60 / 60 / 24 / 7
-		val.WriteString(fmt.Sprintf("%d ", n))
-		val.WriteString("week")
-		if n > 1 {
-			val.WriteString("s")
-		}
-	case diff < MonthsMax:
-		n := diff / 60 / 60 / 24 / 30
-		val.WriteString(fmt.Sprintf("%d ", n))
-		val.WriteString("month")
-		if n > 1 {
-			val.WriteString("s")
-		}
-	default:
-		n := diff / 60 / 60 / 24 / 30 / 365
-		val.WriteString(fmt.Sprintf("%d ", n))
-		val.WriteString("years")
-		if n > 1 {
-			val.WriteString("s")
-		}
-	}
-
-	if o1 < c1 {
-		if len > 0 {
-			val.WriteString(" after")
-		} else {
-			val.WriteString(" from now")
-		}
-	} else {
-		if len > 0 {
-			val.WriteString(" before")
-		} else {
-			val.WriteString(" ago")
-		}
-	}
-
-	return val.String()
+	return c.DiffForHumansWithOptions(nil, other...)
 }
@@ -59,6 +59,86 @@ func TestCarbon_Timestamp(t *testing.T) {
 	as.Equal(len(strconv.Itoa(int(ts))), 10, "Timestamp error")
 }
 
+func TestCarbon_TimestampPrecision(t *testing.T) {
+	as := assert.New(t)
+	c := CreateFromGo(time.Date(2019, 4, 3, 16, 0, 0, 123456789, time.UTC))
+	as.Equal(int64(1554307200123), c.TimestampMilli())
+	as.Equal(int64(1554307200123456), c.TimestampMicro())
+	as.Equal(int64(1554307200123456789), c.TimestampNano())
+
+	as.True(CreateFromTimestampMilli(1554307200123).time.Equal(c.time.Truncate(time.Millisecond)))
+	as.True(CreateFromTimestampMicro(1554307200123456).time.Equal(c.time.Truncate(time.Microsecond)))
+	as.True(CreateFromTimestampNano(1554307200123456789).time.Equal(c.time))
+	as.True(CreateFromTimestampFloat(1554307200.123).time.Round(time.Microsecond).Equal(c.time.Truncate(time.Millisecond)))
+
+	before := CreateFromGo(time.Date(1969, 12, 31, 23, 59, 59, 500000000, time.UTC))
+	as.Equal(int64(-500), before.TimestampMilli())
+	as.True(CreateFromTimestampMilli(-500).time.Equal(before.time))
+	as.True(CreateFromTimestampFloat(-0.5).time.Equal(before.time))
+}
+
+func TestCreateFromTimestampString_Precision(t *testing.T) {
+	as := assert.New(t)
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"1554307200", time.Date(2019, 4, 3, 16, 0, 0, 0, time.UTC)},
+		{"1554307200123", time.Date(2019, 4, 3, 16, 0, 0, 123000000, time.UTC)},
+		{"1554307200123456", time.Date(2019, 4, 3, 16, 0, 0, 123456000, time.UTC)},
+		{"1554307200123456789", time.Date(2019, 4, 3, 16, 0, 0, 123456789, time.UTC)},
+		{"1554307200.123", time.Date(2019, 4, 3, 16, 0, 0, 123000000, time.UTC)},
+		{"1554307200.123456789", time.Date(2019, 4, 3, 16, 0, 0, 123456789, time.UTC)},
+		{"-0.5", time.Date(1969, 12, 31, 23, 59, 59, 500000000, time.UTC)},
+		{"86400", time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		c, err := CreateFromTimestampString(test.value)
+		as.Nil(err, test.value)
+		as.True(c.time.Equal(test.want), test.value)
+	}
+	for _, value := range []string{"", "abc", "1554307200.", "1554307200.1234567891", "1554307200.12a", "1.2.3"} {
+		_, err := CreateFromTimestampString(value)
+		as.Equal(ErrTimestampParse, err, value)
+	}
+}
+
+func TestCarbon_AddSubSecondUnits(t *testing.T) {
+	as := assert.New(t)
+	base := time.Date(2019, 4, 12, 15, 4, 5, 0, time.UTC)
+	as.Equal(base.Add(3*time.Millisecond), CreateFromGo(base).Add(Millisecond, 3).time)
+	as.Equal(base.Add(3*time.Microsecond), CreateFromGo(base).Add(Microsecond, 3).time)
+	as.Equal(base.Add(3*time.Nanosecond), CreateFromGo(base).Add(Nanosecond, 3).time)
+	as.Equal(base.Add(-3*time.Millisecond), CreateFromGo(base).Sub(Millisecond, 3).time)
+	as.Equal(base.Add(-3*time.Microsecond), CreateFromGo(base).Sub(Microsecond, 3).time)
+	as.Equal(base.Add(-3*time.Nanosecond), CreateFromGo(base).Sub(Nanosecond, 3).time)
+
+	// 导出的字段与时间保持一致，进位到秒
+	c := CreateFromGo(base.Add(999*time.Millisecond)).Add(Millisecond, 2)
+	as.Equal(6, c.Second)
+	as.Equal(1, c.Millisecond)
+	as.Equal(1000, c.Microsecond)
+	as.Equal(1000000, c.Nanosecond)
+	c = CreateFromGo(base).Add(Microsecond, 1500)
+	as.Equal(5, c.Second)
+	as.Equal(1, c.Millisecond)
+	as.Equal(1500, c.Microsecond)
+	as.Equal(1500000, c.Nanosecond)
+	c = CreateFromGo(base).Sub(Millisecond, 1)
+	as.Equal(4, c.Minute)
+	as.Equal(4, c.Second)
+	as.Equal(999, c.Millisecond)
+	as.Equal(999000, c.Microsecond)
+	c = CreateFromGo(base).Sub(Microsecond, 1)
+	as.Equal(4, c.Second)
+	as.Equal(999, c.Millisecond)
+	as.Equal(999999, c.Microsecond)
+	as.Equal(999999000, c.Nanosecond)
+	c = CreateFromGo(base).Add(Second, 60)
+	as.Equal(5, c.Minute)
+	as.Equal(5, c.Second)
+}
+
 func TestCreate(t *testing.T) {
 	as := assert.New(t)
 	tz, err := time.LoadLocation("Asia/Shanghai")
@@ -0,0 +1,114 @@
+package carbon
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// chineseUpperNumbers 大写数字，用于票据日期
+var chineseUpperNumbers = []string{"零", "壹", "贰", "叁", "肆", "伍", "陆", "柒", "捌", "玖", "拾"}
+
+// chineseUpperReplacer 将大写数字及繁体写法转换为小写数字，用于解析
+var chineseUpperReplacer = strings.NewReplacer(
+	"壹", "一", "贰", "二", "貳", "二", "叁", "三", "參", "三", "肆", "四", "伍", "五",
+	"陆", "六", "陸", "六", "柒", "七", "捌", "八", "玖", "九", "拾", "十", "○", "〇",
+)
+
+// chineseYear 逐位返回中文数字的年份，如 "二〇一九"、"贰零壹玖"
+func chineseYear(year int, upper bool) string {
+	var b strings.Builder
+	for _, r := range strconv.Itoa(year) {
+		if upper {
+			b.WriteString(chineseUpperNumbers[r-'0'])
+		} else {
+			b.WriteString(lunarNumbers[r-'0'])
+		}
+	}
+	return b.String()
+}
+
+// chineseNumber 返回 1 到 99 的中文数字，如 "四"、"十二"、"二十"
+func chineseNumber(n int) string {
+	switch {
+	case n < 10:
+		return lunarNumbers[n]
+	case n < 20:
+		return "十" + strings.TrimPrefix(lunarNumbers[n%10], "〇")
+	}
+	return lunarNumbers[n/10] + "十" + strings.TrimPrefix(lunarNumbers[n%10], "〇")
+}
+
+// chineseUpperNumber 返回票据日期中大写的月、日。为防止涂改，
+// 1 到 10 月及 1 到 10、20、30 日前加 "零"，11 到 19 写作 "壹拾X"，如 "零肆"、"零壹拾"、"壹拾贰"
+func chineseUpperNumber(n int) string {
+	s := chineseUpperNumbers[n%10]
+	if n >= 10 {
+		s = chineseUpperNumbers[n/10] + "拾"
+		if n%10 > 0 {
+			s += chineseUpperNumbers[n%10]
+		}
+	}
+	if n < 10 || n%10 == 0 {
+		return "零" + s
+	}
+	return s
+}
+
+// ToChineseDateString 返回中文数字的日期，如 "二〇一九年四月十二日"
+func (c *Carbon) ToChineseDateString() string {
+	return chineseYear(c.time.Year(), false) + "年" + chineseNumber(int(c.time.Month())) + "月" + chineseNumber(c.time.Day()) + "日"
+}
+
+// ToChineseUpperDateString 返回票据使用的大写日期，如 "贰零壹玖年零肆月壹拾贰日"
+func (c *Carbon) ToChineseUpperDateString() string {
+	return chineseYear(c.time.Year(), true) + "年" + chineseUpperNumber(int(c.time.Month())) + "月" + chineseUpperNumber(c.time.Day()) + "日"
+}
+
+// chineseDateRegexp 中文日期的格式
+var chineseDateRegexp = regexp.MustCompile(`^\s*([〇零一二三四五六七八九\d]{1,4})\s*年\s*([零一二三四五六七八九十\d]{1,4})\s*月\s*([零一二三四五六七八九十\d]{1,4})\s*[日号]\s*$`)
+
+// ParseChineseDate 解析中文数字或大写的日期，如 "二〇一九年四月十二日"、"贰零壹玖年零肆月壹拾贰日"，
+// 也可以是阿拉伯数字与中文数字混写，如 "2019年四月12日"
+func ParseChineseDate(value string, tz *time.Location) (*Carbon, error) {
+	m := chineseDateRegexp.FindStringSubmatch(chineseUpperReplacer.Replace(value))
+	if m == nil {
+		return &Carbon{}, ErrTimeParse
+	}
+
+	year := 0
+	for _, r := range m[1] {
+		d, ok := chineseDigits[r]
+		if r >= '0' && r <= '9' {
+			d, ok = int(r-'0'), true
+		}
+		if !ok {
+			return &Carbon{}, ErrTimeParse
+		}
+		year = year*10 + d
+	}
+	month, ok := parseChineseDateNumber(m[2])
+	if !ok || month < 1 || month > 12 {
+		return &Carbon{}, ErrTimeParse
+	}
+	day, ok := parseChineseDateNumber(m[3])
+	if !ok || day < 1 {
+		return &Carbon{}, ErrTimeParse
+	}
+
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, tz)
+	// 日期不存在，如 "二月三十日"
+	if t.Day() != day {
+		return &Carbon{}, ErrTimeParse
+	}
+	return CreateFromGo(t), nil
+}
+
+// parseChineseDateNumber 解析月、日，可以是阿拉伯数字或中文数字
+func parseChineseDateNumber(s string) (int, bool) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, true
+	}
+	return parseChineseNumber(s)
+}
@@ -0,0 +1,61 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarbon_ToChineseDateString(t *testing.T) {
+	as := assert.New(t)
+	tests := []struct {
+		year, month, day int
+		lower, upper     string
+	}{
+		{2019, 4, 12, "二〇一九年四月十二日", "贰零壹玖年零肆月壹拾贰日"},
+		{2020, 1, 1, "二〇二〇年一月一日", "贰零贰零年零壹月零壹日"},
+		{2008, 10, 10, "二〇〇八年十月十日", "贰零零捌年零壹拾月零壹拾日"},
+		{2019, 11, 20, "二〇一九年十一月二十日", "贰零壹玖年壹拾壹月零贰拾日"},
+		{2019, 12, 31, "二〇一九年十二月三十一日", "贰零壹玖年壹拾贰月叁拾壹日"},
+		{2019, 3, 30, "二〇一九年三月三十日", "贰零壹玖年零叁月零叁拾日"},
+		{2019, 5, 19, "二〇一九年五月十九日", "贰零壹玖年零伍月壹拾玖日"},
+	}
+	for _, test := range tests {
+		c := Create(test.year, test.month, test.day, 0, 0, 0, time.Local)
+		as.Equal(test.lower, c.ToChineseDateString())
+		as.Equal(test.upper, c.ToChineseUpperDateString())
+		as.Equal(test.lower, c.IsoFormat("Yc年Mc月Dc日"))
+		as.Equal(test.upper, c.IsoFormat("YC年MC月DC日"))
+
+		for _, s := range []string{test.lower, test.upper} {
+			parsed, err := ParseChineseDate(s, time.Local)
+			as.Nil(err, s)
+			as.Equal(c.ToDateString(), parsed.ToDateString(), s)
+		}
+	}
+}
+
+func TestParseChineseDate(t *testing.T) {
+	as := assert.New(t)
+	tests := []struct {
+		value, want string
+	}{
+		{"二零一九年四月十二日", "2019-04-12"},
+		{"二○一九年四月十二号", "2019-04-12"},
+		{"2019年四月12日", "2019-04-12"},
+		{"貳零壹玖年零肆月壹拾貳日", "2019-04-12"},
+		{"贰零贰零年贰月贰拾玖日", "2020-02-29"},
+		{" 二〇一九年 十月 一日 ", "2019-10-01"},
+	}
+	for _, test := range tests {
+		c, err := ParseChineseDate(test.value, time.Local)
+		as.Nil(err, test.value)
+		as.Equal(test.want, c.ToDateString(), test.value)
+	}
+
+	for _, value := range []string{"", "二〇一九年四月", "二〇一九年十三月一日", "二〇一九年二月二十九日", "二〇一九年四月零日", "2019-04-12"} {
+		_, err := ParseChineseDate(value, time.Local)
+		as.Equal(ErrTimeParse, err, value)
+	}
+}
@@ -0,0 +1,105 @@
+package carbon
+
+import (
+	"sort"
+	"time"
+)
+
+// Compare 比较两个时间，a 早于 b 返回 -1，相同返回 0，晚于 b 返回 1，精确到纳秒，可用于 slices.SortFunc
+func Compare(a, b *Carbon) int {
+	switch {
+	case a.time.Before(b.time):
+		return -1
+	case a.time.After(b.time):
+		return 1
+	}
+	return 0
+}
+
+// Min 返回最早的时间，没有参数时返回 nil
+func Min(dates ...*Carbon) *Carbon {
+	var result *Carbon
+	for _, c := range dates {
+		if result == nil || c.time.Before(result.time) {
+			result = c
+		}
+	}
+	return result
+}
+
+// Max 返回最晚的时间，没有参数时返回 nil
+func Max(dates ...*Carbon) *Carbon {
+	var result *Carbon
+	for _, c := range dates {
+		if result == nil || c.time.After(result.time) {
+			result = c
+		}
+	}
+	return result
+}
+
+// distance 返回两个时间相差的绝对时长
+func distance(a, b *Carbon) time.Duration {
+	if d := a.time.Sub(b.time); d >= 0 {
+		return d
+	}
+	return b.time.Sub(a.time)
+}
+
+// Closest 返回与 target 相差最小的时间，相差相同时返回靠前的参数，没有参数时返回 nil
+func Closest(target *Carbon, dates ...*Carbon) *Carbon {
+	var result *Carbon
+	for _, c := range dates {
+		if result == nil || distance(c, target) < distance(result, target) {
+			result = c
+		}
+	}
+	return result
+}
+
+// Farthest 返回与 target 相差最大的时间，相差相同时返回靠前的参数，没有参数时返回 nil
+func Farthest(target *Carbon, dates ...*Carbon) *Carbon {
+	var result *Carbon
+	for _, c := range dates {
+		if result == nil || distance(c, target) > distance(result, target) {
+			result = c
+		}
+	}
+	return result
+}
+
+// Sort 按时间从早到晚排序，相同时间保持原顺序
+func Sort(dates []*Carbon) {
+	sort.SliceStable(dates, func(i, j int) bool { return dates[i].time.Before(dates[j].time) })
+}
+
+// SortDesc 按时间从晚到早排序，相同时间保持原顺序
+func SortDesc(dates []*Carbon) {
+	sort.SliceStable(dates, func(i, j int) bool { return dates[i].time.After(dates[j].time) })
+}
+
+// instantKey 时刻的 map 键，与时区无关
+type instantKey struct {
+	sec  int64
+	nsec int
+}
+
+// keyOf 返回时刻的 map 键
+func keyOf(t time.Time) instantKey {
+	return instantKey{t.Unix(), t.Nanosecond()}
+}
+
+// Unique 按 unit 去重，如 Unique(dates, Day) 每天只保留第一个时间，按各自时区的日历计算，保持原顺序
+func Unique(dates []*Carbon, unit Unit) []*Carbon {
+	seen := make(map[instantKey]bool)
+	var result []*Carbon
+	for _, c := range dates {
+		k := keyOf(c.Floor(unit).time)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		result = append(result, c)
+	}
+	return result
+}
@@ -0,0 +1,86 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// carbonStrings 返回时间的字符串
+func carbonStrings(dates []*Carbon) []string {
+	var result []string
+	for _, c := range dates {
+		result = append(result, c.ToDateTimeString())
+	}
+	return result
+}
+
+func TestCompare(t *testing.T) {
+	as := assert.New(t)
+	a := CreateFromGo(time.Date(2019, 4, 12, 9, 0, 0, 0, time.UTC))
+	b := CreateFromGo(time.Date(2019, 4, 12, 9, 0, 0, 1, time.UTC))
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	as.Equal(-1, Compare(a, b))
+	as.Equal(1, Compare(b, a))
+	as.Equal(0, Compare(a, CreateFromGo(a.time.In(shanghai))))
+}
+
+func TestMinMax(t *testing.T) {
+	as := assert.New(t)
+	dates := []*Carbon{at(12, 0), at(9, 30), at(17, 0), at(9, 30)}
+	as.Equal("2019-04-12 09:30:00", Min(dates...).ToDateTimeString())
+	as.True(dates[1] == Min(dates...))
+	as.Equal("2019-04-12 17:00:00", Max(dates...).ToDateTimeString())
+	as.Nil(Min())
+	as.Nil(Max())
+
+	as.Equal("2019-04-12 12:00:00", Closest(at(13, 0), dates...).ToDateTimeString())
+	as.Equal("2019-04-12 17:00:00", Farthest(at(13, 0), dates...).ToDateTimeString())
+	// 相差相同时返回靠前的参数
+	as.True(dates[0] == Closest(at(14, 30), dates...))
+	as.Nil(Closest(at(13, 0)))
+	as.Nil(Farthest(at(13, 0)))
+}
+
+func TestSort(t *testing.T) {
+	as := assert.New(t)
+	dates := []*Carbon{at(12, 0), at(9, 30), at(17, 0), at(9, 0)}
+	Sort(dates)
+	as.Equal([]string{"2019-04-12 09:00:00", "2019-04-12 09:30:00", "2019-04-12 12:00:00", "2019-04-12 17:00:00"}, carbonStrings(dates))
+	SortDesc(dates)
+	as.Equal([]string{"2019-04-12 17:00:00", "2019-04-12 12:00:00", "2019-04-12 09:30:00", "2019-04-12 09:00:00"}, carbonStrings(dates))
+
+	// 按实际时刻排序，与时区无关
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	first, second := CreateFromGo(time.Date(2019, 4, 12, 16, 0, 0, 0, shanghai)), at(9, 0)
+	mixed := []*Carbon{second, first}
+	Sort(mixed)
+	as.True(mixed[0] == first)
+	Sort(nil)
+}
+
+func TestUnique(t *testing.T) {
+	as := assert.New(t)
+	dates := []*Carbon{
+		at(12, 0),
+		at(9, 30),
+		at(12, 0),
+		at(12, 45),
+		CreateFromGo(time.Date(2019, 4, 13, 8, 0, 0, 0, time.UTC)),
+		CreateFromGo(time.Date(2019, 4, 15, 8, 0, 0, 0, time.UTC)),
+	}
+	as.Equal([]string{
+		"2019-04-12 12:00:00",
+		"2019-04-12 09:30:00",
+		"2019-04-12 12:45:00",
+		"2019-04-13 08:00:00",
+		"2019-04-15 08:00:00",
+	}, carbonStrings(Unique(dates, Nanosecond)))
+	as.Equal([]string{"2019-04-12 12:00:00", "2019-04-12 09:30:00", "2019-04-13 08:00:00", "2019-04-15 08:00:00"}, carbonStrings(Unique(dates, Hour)))
+	as.Equal([]string{"2019-04-12 12:00:00", "2019-04-13 08:00:00", "2019-04-15 08:00:00"}, carbonStrings(Unique(dates, Day)))
+	// 2019-04-15 为星期一
+	as.Equal([]string{"2019-04-12 12:00:00", "2019-04-15 08:00:00"}, carbonStrings(Unique(dates, Week)))
+	as.Equal([]string{"2019-04-12 12:00:00"}, carbonStrings(Unique(dates, Month)))
+	as.Empty(Unique(nil, Day))
+}
@@ -0,0 +1,578 @@
+package carbon
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// cronYears 查找下一次执行时间的最大年数，2 月 29 日最多相隔 8 年
+	cronYears = 10
+	// cronDSTMargin 夏令时调整的最大幅度，在此范围内墙上时间的先后与实际时间的先后可能不一致
+	cronDSTMargin = 3 * time.Hour
+)
+
+// cronDescriptors 预定义的表达式
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// cronMonthNames、cronWeekdayNames 月份及星期的英文缩写
+var (
+	cronMonthNames   = []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	cronWeekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+)
+
+// Cron cron 表达式，支持 5 个字段(分 时 日 月 星期)或 6 个字段(秒 分 时 日 月 星期)，
+// "@daily"、"@every 1h" 等预定义写法，日的 L、L-n、LW、nW 及星期的 nL、n#k 扩展，以及 "CRON_TZ=时区" 前缀
+type Cron struct {
+	expr  string
+	loc   *time.Location
+	every time.Duration
+
+	seconds, minutes, hours, days, months, weekdays uint64
+	// dayStar、weekdayStar 日、星期是否为 * 或 ?，均不为 * 时满足其一即可
+	dayStar, weekdayStar bool
+	// lastDays L、L-n，距月末的天数
+	lastDays []int
+	// lastWeekday LW，当月最后一个工作日
+	lastWeekday bool
+	// nearestWeekdays nW，离 n 日最近的工作日
+	nearestWeekdays []int
+	// lastWeekdays nL，当月最后一个星期 n
+	lastWeekdays uint64
+	// nthWeekdays n#k，当月第 k 个星期 n
+	nthWeekdays [][2]int
+}
+
+// ParseCron 解析 cron 表达式，如 "*/5 * * * *"、"0 30 9 * * MON-FRI"、"0 0 L * *"、"@every 1h30m"、"CRON_TZ=Asia/Shanghai 0 9 * * *"
+func ParseCron(expr string) (*Cron, error) {
+	c := &Cron{expr: expr}
+	fields := strings.Fields(expr)
+	if len(fields) > 0 && (strings.HasPrefix(fields[0], "CRON_TZ=") || strings.HasPrefix(fields[0], "TZ=")) {
+		loc, err := time.LoadLocation(fields[0][strings.IndexByte(fields[0], '=')+1:])
+		if err != nil {
+			return nil, ErrCronParse
+		}
+		c.loc = loc
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return nil, ErrCronParse
+	}
+
+	if fields[0] == "@every" {
+		d, err := ParseHumanDuration(strings.Join(fields[1:], " "))
+		if err != nil || d <= 0 {
+			return nil, ErrCronParse
+		}
+		c.every = d
+		return c, nil
+	}
+	if descriptor, ok := cronDescriptors[strings.ToLower(fields[0])]; ok && len(fields) == 1 {
+		fields = strings.Fields(descriptor)
+	}
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, ErrCronParse
+	}
+
+	var err error
+	if c.seconds, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if c.minutes, err = parseCronField(fields[1], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if c.hours, err = parseCronField(fields[2], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if err = c.parseDays(fields[3]); err != nil {
+		return nil, err
+	}
+	if c.months, err = parseCronField(fields[4], 1, 12, cronMonthNames); err != nil {
+		return nil, err
+	}
+	if err = c.parseWeekdays(fields[5]); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parseCronValue 解析数值或名称
+func parseCronValue(s string, min, max int, names []string) (int, error) {
+	for i, name := range names {
+		if name != "" && strings.ToLower(s) == name {
+			return i, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < min || n > max {
+		return 0, ErrCronParse
+	}
+	return n, nil
+}
+
+// parseCronField 解析以逗号分隔的字段，每项可以是 *、?、a、a-b，并可以带 /step
+func parseCronField(field string, min, max int, names []string) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, ErrCronParse
+			}
+			step, part = n, part[:i]
+		}
+		start, end := min, max
+		switch {
+		case part == "*" || part == "?":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if start, err = parseCronValue(bounds[0], min, max, names); err != nil {
+				return 0, err
+			}
+			if end, err = parseCronValue(bounds[1], min, max, names); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, ErrCronParse
+			}
+		default:
+			var err error
+			if start, err = parseCronValue(part, min, max, names); err != nil {
+				return 0, err
+			}
+			// "a/step" 表示从 a 开始到最大值
+			if step == 1 {
+				end = start
+			}
+		}
+		for i := start; i <= end; i += step {
+			set |= 1 << uint(i)
+		}
+	}
+	return set, nil
+}
+
+// parseDays 解析日，支持 L、L-n、LW、nW
+func (c *Cron) parseDays(field string) error {
+	c.dayStar = field == "*" || field == "?"
+	var plain []string
+	for _, part := range strings.Split(field, ",") {
+		switch {
+		case part == "L":
+			c.lastDays = append(c.lastDays, 0)
+		case part == "LW":
+			c.lastWeekday = true
+		case strings.HasPrefix(part, "L-"):
+			n, err := strconv.Atoi(part[2:])
+			if err != nil || n < 0 || n > 30 {
+				return ErrCronParse
+			}
+			c.lastDays = append(c.lastDays, n)
+		case strings.HasSuffix(part, "W"):
+			n, err := parseCronValue(part[:len(part)-1], 1, 31, nil)
+			if err != nil {
+				return err
+			}
+			c.nearestWeekdays = append(c.nearestWeekdays, n)
+		default:
+			plain = append(plain, part)
+		}
+	}
+	if len(plain) > 0 {
+		set, err := parseCronField(strings.Join(plain, ","), 1, 31, nil)
+		if err != nil {
+			return err
+		}
+		c.days = set
+	}
+	return nil
+}
+
+// parseWeekdays 解析星期，0 和 7 均表示星期日，支持 nL、n#k
+func (c *Cron) parseWeekdays(field string) error {
+	c.weekdayStar = field == "*" || field == "?"
+	var plain []string
+	for _, part := range strings.Split(field, ",") {
+		switch {
+		case strings.Contains(part, "#"):
+			i := strings.IndexByte(part, '#')
+			weekday, err := parseCronValue(part[:i], 0, 7, cronWeekdayNames)
+			if err != nil {
+				return err
+			}
+			k, err := strconv.Atoi(part[i+1:])
+			if err != nil || k < 1 || k > 5 {
+				return ErrCronParse
+			}
+			c.nthWeekdays = append(c.nthWeekdays, [2]int{weekday % 7, k})
+		case len(part) > 1 && strings.HasSuffix(part, "L"):
+			weekday, err := parseCronValue(part[:len(part)-1], 0, 7, cronWeekdayNames)
+			if err != nil {
+				return err
+			}
+			c.lastWeekdays |= 1 << uint(weekday%7)
+		default:
+			plain = append(plain, part)
+		}
+	}
+	if len(plain) > 0 {
+		set, err := parseCronField(strings.Join(plain, ","), 0, 7, cronWeekdayNames)
+		if err != nil {
+			return err
+		}
+		// 7 同 0 表示星期日
+		if set&(1<<7) != 0 {
+			set |= 1
+		}
+		c.weekdays = set &^ (1 << 7)
+	}
+	return nil
+}
+
+// String 返回 cron 表达式
+func (c *Cron) String() string {
+	return c.expr
+}
+
+// dayMatches 判断某天是否满足日及星期字段
+func (c *Cron) dayMatches(year int, month time.Month, day int) bool {
+	if c.dayStar && c.weekdayStar {
+		return true
+	}
+	if c.weekdayStar {
+		return c.dayFieldMatches(year, month, day)
+	}
+	if c.dayStar {
+		return c.weekdayFieldMatches(year, month, day)
+	}
+	return c.dayFieldMatches(year, month, day) || c.weekdayFieldMatches(year, month, day)
+}
+
+// dayFieldMatches 判断某天是否满足日字段
+func (c *Cron) dayFieldMatches(year int, month time.Month, day int) bool {
+	if c.days&(1<<uint(day)) != 0 {
+		return true
+	}
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, n := range c.lastDays {
+		if day == last-n {
+			return true
+		}
+	}
+	if c.lastWeekday && day == nearestWeekday(year, month, last) {
+		return true
+	}
+	for _, n := range c.nearestWeekdays {
+		if n <= last && day == nearestWeekday(year, month, n) {
+			return true
+		}
+	}
+	return false
+}
+
+// weekdayFieldMatches 判断某天是否满足星期字段
+func (c *Cron) weekdayFieldMatches(year int, month time.Month, day int) bool {
+	weekday := int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday())
+	if c.weekdays&(1<<uint(weekday)) != 0 {
+		return true
+	}
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if c.lastWeekdays&(1<<uint(weekday)) != 0 && day+7 > last {
+		return true
+	}
+	for _, nth := range c.nthWeekdays {
+		if weekday == nth[0] && (day-1)/7+1 == nth[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// nearestWeekday 返回当月离 day 日最近的工作日，不跨月
+func nearestWeekday(year int, month time.Month, day int) int {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return 3
+		}
+		return day - 1
+	case time.Sunday:
+		if day == last {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+// nextBit 返回 set 中不小于 from 的最小值，没有时返回 -1
+func nextBit(set uint64, from int) int {
+	for i := from; i < 64; i++ {
+		if set&(1<<uint(i)) != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// prevBit 返回 set 中不大于 from 的最大值，没有时返回 -1
+func prevBit(set uint64, from int) int {
+	for i := from; i >= 0; i-- {
+		if set&(1<<uint(i)) != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// nextWall 返回不早于墙上时间 w 的第一个满足表达式的墙上时间，墙上时间以 UTC 表示，不涉及夏令时
+func (c *Cron) nextWall(w time.Time, limit int) (time.Time, bool) {
+	for w.Year() <= limit {
+		y, mo, d := w.Date()
+		if c.months&(1<<uint(mo)) == 0 {
+			w = time.Date(y, mo+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.dayMatches(y, mo, d) {
+			w = time.Date(y, mo, d+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		h := nextBit(c.hours, w.Hour())
+		if h < 0 {
+			w = time.Date(y, mo, d+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if h != w.Hour() {
+			w = time.Date(y, mo, d, h, 0, 0, 0, time.UTC)
+		}
+		m := nextBit(c.minutes, w.Minute())
+		if m < 0 {
+			w = time.Date(y, mo, d, h+1, 0, 0, 0, time.UTC)
+			continue
+		}
+		if m != w.Minute() {
+			w = time.Date(y, mo, d, h, m, 0, 0, time.UTC)
+		}
+		s := nextBit(c.seconds, w.Second())
+		if s < 0 {
+			w = time.Date(y, mo, d, h, m+1, 0, 0, time.UTC)
+			continue
+		}
+		return time.Date(y, mo, d, h, m, s, 0, time.UTC), true
+	}
+	return time.Time{}, false
+}
+
+// prevWall 返回不晚于墙上时间 w 的最后一个满足表达式的墙上时间
+func (c *Cron) prevWall(w time.Time, limit int) (time.Time, bool) {
+	for w.Year() >= limit {
+		y, mo, d := w.Date()
+		if c.months&(1<<uint(mo)) == 0 {
+			w = time.Date(y, mo, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+			continue
+		}
+		if !c.dayMatches(y, mo, d) {
+			w = time.Date(y, mo, d, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+			continue
+		}
+		h := prevBit(c.hours, w.Hour())
+		if h < 0 {
+			w = time.Date(y, mo, d, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+			continue
+		}
+		if h != w.Hour() {
+			w = time.Date(y, mo, d, h, 59, 59, 0, time.UTC)
+		}
+		m := prevBit(c.minutes, w.Minute())
+		if m < 0 {
+			w = time.Date(y, mo, d, h, 0, 0, 0, time.UTC).Add(-time.Second)
+			continue
+		}
+		if m != w.Minute() {
+			w = time.Date(y, mo, d, h, m, 59, 0, time.UTC)
+		}
+		s := prevBit(c.seconds, w.Second())
+		if s < 0 {
+			w = time.Date(y, mo, d, h, m, 0, 0, time.UTC).Add(-time.Second)
+			continue
+		}
+		return time.Date(y, mo, d, h, m, s, 0, time.UTC), true
+	}
+	return time.Time{}, false
+}
+
+// wallClock 返回 t 的墙上时间，以 UTC 表示
+func wallClock(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	return time.Date(y, mo, d, t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// stableOffset 判断 t 前后 cronDSTMargin 内时区偏移是否不变
+func stableOffset(t time.Time) bool {
+	_, before := t.Add(-cronDSTMargin).Zone()
+	_, now := t.Zone()
+	_, after := t.Add(cronDSTMargin).Zone()
+	return before == now && now == after
+}
+
+// wallInstants 返回墙上时间 w 在 loc 时区对应的时刻，按时间排序。
+// 夏令时结束时重复的时间返回两个时刻，夏令时开始时跳过的时间返回调整的时刻
+func wallInstants(w time.Time, loc *time.Location) []time.Time {
+	t := time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), loc)
+	var result []time.Time
+	for _, probe := range []time.Time{t.Add(-12 * time.Hour), t, t.Add(12 * time.Hour)} {
+		_, offset := probe.Zone()
+		x := w.Add(-time.Duration(offset) * time.Second).In(loc)
+		if wallClock(x).Add(time.Duration(x.Nanosecond())).Equal(w) && (len(result) == 0 || !result[len(result)-1].Equal(x)) {
+			result = append(result, x)
+		}
+	}
+	if len(result) == 0 {
+		// 不存在的时间，二分查找墙上时间不早于 w 的第一个时刻
+		_, before := t.Add(-12 * time.Hour).Zone()
+		_, after := t.Add(12 * time.Hour).Zone()
+		lo, hi := w.Add(-time.Duration(before)*time.Second), w.Add(-time.Duration(after)*time.Second)
+		if hi.Before(lo) {
+			lo, hi = hi, lo
+		}
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+			if wallClock(mid.In(loc)).Before(w) {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		return []time.Time{hi.In(loc)}
+	}
+	if len(result) == 2 && result[1].Before(result[0]) {
+		result[0], result[1] = result[1], result[0]
+	}
+	return result
+}
+
+// instants 返回墙上时间 w 在 loc 时区对应的执行时间。
+// 夏令时开始时跳过的时间在调整的时刻执行；夏令时结束时重复的时间只在第一次执行，小时为 * 时两次都执行
+func (c *Cron) instants(w time.Time, loc *time.Location) []time.Time {
+	result := wallInstants(w, loc)
+	if len(result) > 1 && c.hours != 1<<24-1 {
+		return result[:1]
+	}
+	return result
+}
+
+// location 返回计算使用的时区，未指定 CRON_TZ 时使用 from 的时区
+func (c *Cron) location(from *Carbon) *time.Location {
+	if c.loc != nil {
+		return c.loc
+	}
+	return from.time.Location()
+}
+
+// Next 返回 from 之后的下一次执行时间，不存在时返回 nil。"@every" 按 time.Truncate 对齐公元 1 年 1 月 1 日 UTC 零点，
+// 能整除 24 小时的间隔对齐每天的 UTC 零点，如 "@every 1h" 在每个 UTC 整点执行，"@every 7m" 则不对齐每天的零点
+func (c *Cron) Next(from *Carbon) *Carbon {
+	f := from.time.In(c.location(from))
+	if c.every > 0 {
+		return CreateFromGo(f.Truncate(c.every).Add(c.every))
+	}
+
+	w := wallClock(f)
+	if !stableOffset(f) {
+		w = w.Add(-cronDSTMargin)
+	}
+	var best time.Time
+	found := false
+	for {
+		var ok bool
+		if w, ok = c.nextWall(w, f.Year()+cronYears); !ok {
+			break
+		}
+		// 夏令时前后墙上时间靠后的也可能更早执行，需多查找一段时间
+		if found && (stableOffset(best) || w.After(wallClock(best).Add(cronDSTMargin))) {
+			break
+		}
+		for _, x := range c.instants(w, f.Location()) {
+			if x.After(f) && (!found || x.Before(best)) {
+				best, found = x, true
+			}
+		}
+		w = w.Add(time.Second)
+	}
+	if !found {
+		return nil
+	}
+	return CreateFromGo(best)
+}
+
+// Prev 返回 from 之前的上一次执行时间，不存在时返回 nil
+func (c *Cron) Prev(from *Carbon) *Carbon {
+	f := from.time.In(c.location(from))
+	if c.every > 0 {
+		t := f.Truncate(c.every)
+		if t.Equal(f) {
+			t = t.Add(-c.every)
+		}
+		return CreateFromGo(t)
+	}
+
+	w := wallClock(f)
+	if !stableOffset(f) {
+		w = w.Add(cronDSTMargin)
+	}
+	var best time.Time
+	found := false
+	for {
+		var ok bool
+		if w, ok = c.prevWall(w, f.Year()-cronYears); !ok {
+			break
+		}
+		if found && (stableOffset(best) || w.Before(wallClock(best).Add(-cronDSTMargin))) {
+			break
+		}
+		for _, x := range c.instants(w, f.Location()) {
+			if x.Before(f) && (!found || x.After(best)) {
+				best, found = x, true
+			}
+		}
+		w = w.Add(-time.Second)
+	}
+	if !found {
+		return nil
+	}
+	return CreateFromGo(best)
+}
+
+// NextN 返回 from 之后的 n 次执行时间，不足 n 次时返回全部
+func (c *Cron) NextN(from *Carbon, n int) []*Carbon {
+	var result []*Carbon
+	for len(result) < n {
+		if from = c.Next(from); from == nil {
+			break
+		}
+		result = append(result, from)
+	}
+	return result
+}
+
+// Matches 判断 t 是否是一次执行时间
+func (c *Cron) Matches(t *Carbon) bool {
+	next := c.Next(CreateFromGo(t.time.Add(-time.Nanosecond)))
+	return next != nil && next.time.Equal(t.time)
+}
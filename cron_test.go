@@ -0,0 +1,145 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCron_Next(t *testing.T) {
+	as := assert.New(t)
+	from := CreateFromGo(time.Date(2019, 4, 12, 15, 4, 5, 0, time.UTC))
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"*/15 * * * *", []string{"2019-04-12 15:15:00", "2019-04-12 15:30:00", "2019-04-12 15:45:00"}},
+		{"*/20 * * * * *", []string{"2019-04-12 15:04:20", "2019-04-12 15:04:40", "2019-04-12 15:05:00"}},
+		{"30 9 * * MON-FRI", []string{"2019-04-15 09:30:00", "2019-04-16 09:30:00", "2019-04-17 09:30:00"}},
+		{"0 0 1,15 * *", []string{"2019-04-15 00:00:00", "2019-05-01 00:00:00", "2019-05-15 00:00:00"}},
+		{"0 12 * JAN,JUL *", []string{"2019-07-01 12:00:00", "2019-07-02 12:00:00", "2019-07-03 12:00:00"}},
+		{"0 0 L * *", []string{"2019-04-30 00:00:00", "2019-05-31 00:00:00", "2019-06-30 00:00:00"}},
+		{"0 0 L-2 * *", []string{"2019-04-28 00:00:00", "2019-05-29 00:00:00", "2019-06-28 00:00:00"}},
+		{"0 0 LW * *", []string{"2019-04-30 00:00:00", "2019-05-31 00:00:00", "2019-06-28 00:00:00"}},
+		{"0 0 15W * *", []string{"2019-04-15 00:00:00", "2019-05-15 00:00:00", "2019-06-14 00:00:00"}},
+		{"0 0 1W * *", []string{"2019-05-01 00:00:00", "2019-06-03 00:00:00", "2019-07-01 00:00:00"}},
+		{"0 0 * * 5L", []string{"2019-04-26 00:00:00", "2019-05-31 00:00:00", "2019-06-28 00:00:00"}},
+		{"0 0 * * MON#2", []string{"2019-05-13 00:00:00", "2019-06-10 00:00:00", "2019-07-08 00:00:00"}},
+		{"0 0 13 * 5", []string{"2019-04-13 00:00:00", "2019-04-19 00:00:00", "2019-04-26 00:00:00"}},
+		{"0 0 29 2 *", []string{"2020-02-29 00:00:00", "2024-02-29 00:00:00", "2028-02-29 00:00:00"}},
+		{"0 0 * * 7", []string{"2019-04-14 00:00:00", "2019-04-21 00:00:00", "2019-04-28 00:00:00"}},
+		{"@daily", []string{"2019-04-13 00:00:00", "2019-04-14 00:00:00", "2019-04-15 00:00:00"}},
+		{"@monthly", []string{"2019-05-01 00:00:00", "2019-06-01 00:00:00", "2019-07-01 00:00:00"}},
+		{"@every 90m", []string{"2019-04-12 16:30:00", "2019-04-12 18:00:00", "2019-04-12 19:30:00"}},
+		{"@every 1 day", []string{"2019-04-13 00:00:00", "2019-04-14 00:00:00", "2019-04-15 00:00:00"}},
+	}
+	for _, test := range tests {
+		cron, err := ParseCron(test.expr)
+		as.Nil(err, test.expr)
+		var got []string
+		for _, c := range cron.NextN(from, 3) {
+			got = append(got, c.ToDateTimeString())
+		}
+		as.Equal(test.want, got, test.expr)
+		as.Equal(test.expr, cron.String())
+	}
+
+	cron, _ := ParseCron("0 0 30 2 *")
+	as.Nil(cron.Next(from))
+	as.Empty(cron.NextN(from, 3))
+}
+
+func TestCron_Prev(t *testing.T) {
+	as := assert.New(t)
+	from := CreateFromGo(time.Date(2019, 4, 12, 15, 4, 5, 0, time.UTC))
+	tests := []struct {
+		expr, want string
+	}{
+		{"*/15 * * * *", "2019-04-12 15:00:00"},
+		{"0 0 L * *", "2019-03-31 00:00:00"},
+		{"0 0 * * 5L", "2019-03-29 00:00:00"},
+		{"30 9 * * MON-FRI", "2019-04-12 09:30:00"},
+		{"0 0 29 2 *", "2016-02-29 00:00:00"},
+		{"@every 1h", "2019-04-12 15:00:00"},
+	}
+	for _, test := range tests {
+		cron, err := ParseCron(test.expr)
+		as.Nil(err, test.expr)
+		as.Equal(test.want, cron.Prev(from).ToDateTimeString(), test.expr)
+	}
+
+	cron, _ := ParseCron("@every 1h")
+	as.Equal("2019-04-12 14:00:00", cron.Prev(CreateFromGo(time.Date(2019, 4, 12, 15, 0, 0, 0, time.UTC))).ToDateTimeString())
+}
+
+func TestCron_Matches(t *testing.T) {
+	as := assert.New(t)
+	cron, _ := ParseCron("0 30 9 * * MON-FRI")
+	as.True(cron.Matches(CreateFromGo(time.Date(2019, 4, 12, 9, 30, 0, 0, time.UTC))))
+	as.False(cron.Matches(CreateFromGo(time.Date(2019, 4, 12, 9, 30, 1, 0, time.UTC))))
+	as.False(cron.Matches(CreateFromGo(time.Date(2019, 4, 13, 9, 30, 0, 0, time.UTC))))
+}
+
+func TestCron_TimeZone(t *testing.T) {
+	as := assert.New(t)
+	cron, err := ParseCron("CRON_TZ=Asia/Tokyo 0 9 * * *")
+	as.Nil(err)
+	next := cron.Next(CreateFromGo(time.Date(2019, 4, 12, 0, 0, 0, 0, time.UTC)))
+	as.True(next.time.Equal(time.Date(2019, 4, 13, 0, 0, 0, 0, time.UTC)))
+	as.Equal("Asia/Tokyo", next.time.Location().String())
+
+	_, err = ParseCron("CRON_TZ=Nowhere/City 0 9 * * *")
+	as.Equal(ErrCronParse, err)
+}
+
+func TestCron_DST(t *testing.T) {
+	as := assert.New(t)
+	ny, err := time.LoadLocation("America/New_York")
+	as.Nil(err)
+	format := "2006-01-02 15:04:05 MST"
+	tests := []struct {
+		expr string
+		from time.Time
+		want []string
+	}{
+		// 2019-03-10 02:00 EST 调整为 03:00 EDT，跳过的时间在调整时执行
+		{"30 2 * * *", time.Date(2019, 3, 9, 12, 0, 0, 0, ny), []string{"2019-03-10 03:00:00 EDT", "2019-03-11 02:30:00 EDT"}},
+		{"0,30 * * * *", time.Date(2019, 3, 10, 1, 15, 0, 0, ny), []string{"2019-03-10 01:30:00 EST", "2019-03-10 03:00:00 EDT", "2019-03-10 03:30:00 EDT"}},
+		// 2019-11-03 02:00 EDT 调整为 01:00 EST，重复的时间只执行一次
+		{"30 1 * * *", time.Date(2019, 11, 2, 12, 0, 0, 0, ny), []string{"2019-11-03 01:30:00 EDT", "2019-11-04 01:30:00 EST"}},
+		// 小时为 * 时按实际时间执行
+		{"30 * * * *", time.Date(2019, 11, 3, 0, 45, 0, 0, ny), []string{"2019-11-03 01:30:00 EDT", "2019-11-03 01:30:00 EST", "2019-11-03 02:30:00 EST"}},
+		{"@daily", time.Date(2019, 3, 9, 12, 0, 0, 0, ny), []string{"2019-03-10 00:00:00 EST", "2019-03-11 00:00:00 EDT"}},
+	}
+	for _, test := range tests {
+		cron, err := ParseCron(test.expr)
+		as.Nil(err, test.expr)
+		var got []string
+		for _, c := range cron.NextN(CreateFromGo(test.from), len(test.want)) {
+			got = append(got, c.Format(format))
+		}
+		as.Equal(test.want, got, test.expr)
+	}
+
+	cron, _ := ParseCron("30 * * * *")
+	prev := cron.Prev(CreateFromGo(time.Date(2019, 11, 3, 2, 30, 0, 0, ny)))
+	as.Equal("2019-11-03 01:30:00 EST", prev.Format(format))
+	prev = cron.Prev(prev)
+	as.Equal("2019-11-03 01:30:00 EDT", prev.Format(format))
+	cron, _ = ParseCron("30 2 * * *")
+	as.Equal("2019-03-10 03:00:00 EDT", cron.Prev(CreateFromGo(time.Date(2019, 3, 10, 4, 0, 0, 0, ny))).Format(format))
+	as.True(cron.Matches(CreateFromGo(time.Date(2019, 3, 10, 3, 0, 0, 0, ny))))
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	as := assert.New(t)
+	for _, expr := range []string{
+		"", "* * * *", "* * * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * 32 * *",
+		"* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "* * * * MON#6", "* * L-31 * *",
+		"* * 32W * *", "@every", "@every -1h", "@every soon", "@weekly 1", "a * * * *",
+	} {
+		_, err := ParseCron(expr)
+		as.Equal(ErrCronParse, err, expr)
+	}
+}
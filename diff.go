@@ -0,0 +1,295 @@
+package carbon
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rounding 取整方式
+type Rounding int
+
+const (
+	// RoundingFloor 向下取整
+	RoundingFloor Rounding = iota
+	// RoundingRound 四舍五入
+	RoundingRound
+	// RoundingCeil 向上取整
+	RoundingCeil
+)
+
+// DiffOptions DiffForHumansWithOptions 的选项
+type DiffOptions struct {
+	// Parts 输出的单位个数，如 2 输出 "2 days 3 hours"，默认为 1
+	Parts int
+	// Short 使用单位简写，如 "3h"
+	Short bool
+	// Absolute 不输出 ago、from now 等方向
+	Absolute bool
+	// JustNowThreshold 相差小于该值时输出 "just now"，为 0 时不启用
+	JustNowThreshold time.Duration
+	// Rounding 最后一个单位的取整方式，默认向下取整
+	Rounding Rounding
+	// Join 使用逗号和 and 连接各个单位，如 "2 days and 3 hours"
+	Join bool
+	// Locale 语言，如 "en"、"zh-CN"，默认为 "en"
+	Locale string
+}
+
+// diffUnits DiffForHumans 使用的单位，从大到小排列
+var diffUnits = []Unit{Year, Month, Week, Day, Hour, Minute, Second}
+
+// DiffFormats DiffForHumans 使用的文本，模板中的 %d 替换为数值，%s 替换为时间差
+type DiffFormats struct {
+	// Units 各单位的单数、复数及简写模板，如 {"%d day", "%d days", "%dd"}
+	Units map[Unit][3]string
+	// Plurals 各单位按 CLDR 复数类别的模板，如俄语的 {"one": "%d день", "few": "%d дня", "many": "%d дней"}，
+	// 为空或没有对应类别时使用 Units
+	Plurals map[Unit]map[string]string
+	// RelativePlurals Ago、FromNow 中各单位按复数类别的模板，如德语的 {"other": "%d Tagen"}，没有对应类别时使用 Plurals、Units
+	RelativePlurals map[Unit]map[string]string
+	// PluralRule 返回数值的 CLDR 复数类别，为空时使用 Units
+	PluralRule func(n int) string
+	// Ago 过去的时间，如 "%s ago"
+	Ago string
+	// FromNow 将来的时间，如 "%s from now"
+	FromNow string
+	// Before 早于比较对象，如 "%s before"
+	Before string
+	// After 晚于比较对象，如 "%s after"
+	After string
+	// JustNow 刚刚，如 "just now"
+	JustNow string
+	// Separator 各单位之间的分隔符，如 " "
+	Separator string
+	// ListSeparator、LastSeparator 启用 Join 时的分隔符，如 ", " 及 " and "
+	ListSeparator, LastSeparator string
+}
+
+var (
+	diffFormatsMu sync.RWMutex
+	diffFormats   = map[string]*DiffFormats{}
+)
+
+// RegisterDiffFormats 注册或替换 locale 语言的 DiffForHumans 文本，优先于 RegisterLocale 注册的语言数据
+func RegisterDiffFormats(locale string, formats *DiffFormats) {
+	diffFormatsMu.Lock()
+	defer diffFormatsMu.Unlock()
+	diffFormats[locale] = formats
+}
+
+// diffFormatsOf 返回 locale 语言的文本，按回退链查找
+func diffFormatsOf(locale string) *DiffFormats {
+	for _, name := range LocaleFallbacks(locale) {
+		diffFormatsMu.RLock()
+		f, ok := diffFormats[name]
+		diffFormatsMu.RUnlock()
+		if ok {
+			return f
+		}
+	}
+	return compiledLocaleOf(locale).diff
+}
+
+// addMonthsNoOverflow 增加 months 个月，日期超出目标月份天数时取该月最后一天
+func addMonthsNoOverflow(t time.Time, months int) time.Time {
+	y, m, d := t.Date()
+	last := time.Date(y, m+time.Month(months)+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	if d > last {
+		d = last
+	}
+	return time.Date(y, m+time.Month(months), d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// addUnit 按日历给 t 增加 value 个 unit
+func addUnit(t time.Time, unit Unit, value int) time.Time {
+	switch unit {
+	case Year:
+		return addMonthsNoOverflow(t, value*12)
+	case QuarterUnit:
+		return addMonthsNoOverflow(t, value*3)
+	case Month:
+		return addMonthsNoOverflow(t, value)
+	case Week:
+		return t.AddDate(0, 0, value*7)
+	case Day:
+		return t.AddDate(0, 0, value)
+	case Hour:
+		return t.Add(time.Duration(value) * time.Hour)
+	case Minute:
+		return t.Add(time.Duration(value) * time.Minute)
+	case Second:
+		return t.Add(time.Duration(value) * time.Second)
+	case Millisecond:
+		return t.Add(time.Duration(value) * time.Millisecond)
+	case Microsecond:
+		return t.Add(time.Duration(value) * time.Microsecond)
+	case Nanosecond:
+		return t.Add(time.Duration(value) * time.Nanosecond)
+	}
+	return t
+}
+
+// calendarDiff 返回 start 到 end(start 不晚于 end)按日历计算的年、月、周、日、时、分、秒
+func calendarDiff(start, end time.Time) []int {
+	values := make([]int, len(diffUnits))
+
+	months := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+	if months > 0 && addMonthsNoOverflow(start, months).After(end) {
+		months--
+	}
+	values[0], values[1] = months/12, months%12
+	anchor := addMonthsNoOverflow(start, months)
+
+	ay, am, ad := anchor.Date()
+	ey, em, ed := end.Date()
+	days := int(time.Date(ey, em, ed, 0, 0, 0, 0, time.UTC).Sub(time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+	if days > 0 && anchor.AddDate(0, 0, days).After(end) {
+		days--
+	}
+	values[2], values[3] = days/7, days%7
+	anchor = anchor.AddDate(0, 0, days)
+
+	rest := end.Sub(anchor)
+	values[4] = int(rest / time.Hour)
+	values[5] = int(rest % time.Hour / time.Minute)
+	values[6] = int(rest % time.Minute / time.Second)
+	return values
+}
+
+// selectParts 返回第一个非零单位的下标及输出的最后一个单位的下标
+func selectParts(values []int, parts int) (int, int) {
+	first := len(values) - 1
+	for i, v := range values {
+		if v != 0 {
+			first = i
+			break
+		}
+	}
+	last := first + parts - 1
+	if last >= len(values) {
+		last = len(values) - 1
+	}
+	return first, last
+}
+
+// humanDiff 按选项计算 start 到 end 输出的各单位数值，返回数值及输出范围
+func humanDiff(start, end time.Time, opts *DiffOptions) ([]int, int, int) {
+	parts := opts.Parts
+	if parts < 1 {
+		parts = 1
+	}
+	values := calendarDiff(start, end)
+	first, last := selectParts(values, parts)
+	if opts.Rounding == RoundingFloor {
+		return values, first, last
+	}
+
+	anchor := start
+	for i := 0; i <= last; i++ {
+		anchor = addUnit(anchor, diffUnits[i], values[i])
+	}
+	if !anchor.Before(end) {
+		return values, first, last
+	}
+	next := addUnit(anchor, diffUnits[last], 1)
+	fraction := float64(end.Sub(anchor)) / float64(next.Sub(anchor))
+	if opts.Rounding == RoundingCeil || fraction >= 0.5 {
+		// 进位后重新计算，超出的部分会自动进到更大的单位
+		values = calendarDiff(start, next)
+		first, last = selectParts(values, parts)
+	}
+	return values, first, last
+}
+
+// DiffForHumansWithOptions 返回人类可读的时间差，other 为空时与现在比较
+func (c *Carbon) DiffForHumansWithOptions(opts *DiffOptions, other ...*Carbon) string {
+	if opts == nil {
+		opts = &DiffOptions{}
+	}
+	f := diffFormatsOf(opts.Locale)
+	o := Now()
+	if len(other) > 0 {
+		o = other[0]
+	}
+	start, end := c.time, o.time.In(c.time.Location())
+	past := !start.After(end)
+	if !past {
+		start, end = end, start
+	}
+	if opts.JustNowThreshold > 0 && end.Sub(start) < opts.JustNowThreshold {
+		return f.JustNow
+	}
+
+	values, first, last := humanDiff(start, end, opts)
+	// Ago、FromNow 中的单位可能使用不同的形式，如德语的与格
+	relative := !opts.Absolute && len(other) == 0
+	var items []string
+	for i := first; i <= last; i++ {
+		if values[i] == 0 && i != first {
+			continue
+		}
+		if relative {
+			items = append(items, f.formatRelativeUnit(values[i], diffUnits[i], opts.Short))
+		} else {
+			items = append(items, f.formatUnit(values[i], diffUnits[i], opts.Short))
+		}
+	}
+
+	s := f.join(items, opts.Join)
+	switch {
+	case opts.Absolute:
+		return s
+	case past && len(other) > 0:
+		return fmt.Sprintf(f.Before, s)
+	case past:
+		return fmt.Sprintf(f.Ago, s)
+	case len(other) > 0:
+		return fmt.Sprintf(f.After, s)
+	default:
+		return fmt.Sprintf(f.FromNow, s)
+	}
+}
+
+// formatUnit 返回如 "1 day"、"3 hours"、"3h" 的字符串
+func (f *DiffFormats) formatUnit(value int, unit Unit, short bool) string {
+	names := f.Units[unit]
+	if !short && f.PluralRule != nil {
+		if tpl, ok := f.Plurals[unit][f.PluralRule(value)]; ok {
+			return fmt.Sprintf(tpl, value)
+		}
+	}
+	switch {
+	case short:
+		return fmt.Sprintf(names[2], value)
+	case value == 1:
+		return fmt.Sprintf(names[0], value)
+	default:
+		return fmt.Sprintf(names[1], value)
+	}
+}
+
+// formatRelativeUnit 返回 Ago、FromNow 中的单位字符串，RelativePlurals 中没有对应模板时同 formatUnit
+func (f *DiffFormats) formatRelativeUnit(value int, unit Unit, short bool) string {
+	if !short {
+		category := "other"
+		if f.PluralRule != nil {
+			category = f.PluralRule(value)
+		} else if value == 1 {
+			category = "one"
+		}
+		if tpl, ok := f.RelativePlurals[unit][category]; ok {
+			return fmt.Sprintf(tpl, value)
+		}
+	}
+	return f.formatUnit(value, unit, short)
+}
+
+// join 连接各个单位，join 为 true 时如 "1 day, 2 hours and 3 minutes"
+func (f *DiffFormats) join(items []string, join bool) string {
+	if !join || len(items) < 2 {
+		return strings.Join(items, f.Separator)
+	}
+	return strings.Join(items[:len(items)-1], f.ListSeparator) + f.LastSeparator + items[len(items)-1]
+}
@@ -0,0 +1,365 @@
+package carbon
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// relativeDays 表示某一天的词语及其相对今天的天数
+var relativeDays = map[string]int{
+	"today":                    0,
+	"yesterday":                -1,
+	"tomorrow":                 1,
+	"the day before yesterday": -2,
+	"the day after tomorrow":   2,
+	"今天":                       0,
+	"昨天":                       -1,
+	"明天":                       1,
+	"前天":                       -2,
+	"后天":                       2,
+	"大前天":                      -3,
+	"大后天":                      3,
+}
+
+// justNowWords 表示刚刚的词语，各语言 DiffFormats.JustNow 也会被识别
+var justNowWords = []string{"now", "right now", "a moment ago", "moments ago", "a few seconds ago", "刚才", "现在"}
+
+// extraUnitWords DiffFormats 之外可识别的单位写法
+var extraUnitWords = map[string]Unit{
+	"yr": Year, "yrs": Year,
+	"mos": Month, "月": Month,
+	"wk": Week, "wks": Week, "星期": Week, "个星期": Week, "礼拜": Week, "个礼拜": Week,
+	"日":  Day,
+	"hr": Hour, "hrs": Hour, "个小时": Hour, "钟头": Hour, "个钟头": Hour,
+	"min": Minute, "mins": Minute, "分": Minute,
+	"sec": Second, "secs": Second, "秒钟": Second,
+	"us": Microsecond, "μs": Microsecond,
+}
+
+// diffExtraSeparators DiffFormats 之外可识别的单位之间的分隔符
+var diffExtraSeparators = []string{",", "，", "、", "and", "又", "零"}
+
+// relativeAffix 表示方向的前缀、后缀及其符号
+type relativeAffix struct {
+	prefix, suffix string
+	sign           int
+}
+
+// extraAffixes DiffFormats 之外可识别的方向写法
+var extraAffixes = []relativeAffix{
+	{"in ", "", 1},
+	{"", " later", 1},
+	{"", " earlier", -1},
+	{"", "之前", -1},
+	{"", "以前", -1},
+	{"", "之后", 1},
+	{"", "以后", 1},
+}
+
+// subUnits 各单位换算为更小的单位，用于小数及 "半" 的数值
+var subUnits = map[Unit]struct {
+	unit  Unit
+	count int
+}{
+	Year:        {Month, 12},
+	Month:       {Day, 30},
+	Week:        {Day, 7},
+	Day:         {Hour, 24},
+	Hour:        {Minute, 60},
+	Minute:      {Second, 60},
+	Second:      {Millisecond, 1000},
+	Millisecond: {Microsecond, 1000},
+	Microsecond: {Nanosecond, 1000},
+}
+
+// ParseDiffForHumans 解析 DiffForHumans 输出的相对时间，如 "3 hours ago"、"2 days from now"、"5分钟前"、"刚刚"，
+// 以及 "an hour ago"、"yesterday" 等常见写法，返回相对于 reference 的大致时间及其精度单位。
+// reference 为 nil 时相对于现在，没有方向的时间差(如 "2d 3h")视为过去的时间
+func ParseDiffForHumans(value string, reference *Carbon) (*Carbon, Unit, error) {
+	if reference == nil {
+		reference = Now()
+	}
+	s := strings.Join(strings.Fields(strings.ToLower(value)), " ")
+	if s == "" {
+		return &Carbon{}, Second, ErrDiffParse
+	}
+
+	formats := allDiffFormats()
+	for _, f := range formats {
+		if s == strings.ToLower(f.JustNow) {
+			return CreateFromGo(reference.time), Second, nil
+		}
+	}
+	for _, w := range justNowWords {
+		if s == w {
+			return CreateFromGo(reference.time), Second, nil
+		}
+	}
+	if days, ok := relativeDays[s]; ok {
+		return CreateFromGo(reference.time.AddDate(0, 0, days)), Day, nil
+	}
+
+	body, sign := trimRelativeAffix(s, formats)
+	pairs, ok := parseDiffParts(body, unitWords(formats), diffSeparators(formats))
+	if !ok {
+		return &Carbon{}, Second, ErrDiffParse
+	}
+	pairs = wholeParts(pairs)
+
+	// 从大到小依次增加各单位，与 DiffForHumans 的计算顺序一致
+	sort.SliceStable(pairs, func(i, j int) bool { return unitIndex(pairs[i].unit) < unitIndex(pairs[j].unit) })
+	t := reference.time
+	precision := Year
+	for _, p := range pairs {
+		t = addUnit(t, p.unit, sign*int(p.value))
+		if unitIndex(p.unit) > unitIndex(precision) {
+			precision = p.unit
+		}
+	}
+	return CreateFromGo(t), precision, nil
+}
+
+// allDiffFormats 返回所有已注册语言的文本，"en" 在最前，其余按名称排列
+func allDiffFormats() []*DiffFormats {
+	names := append([]string{"en"}, Locales()...)
+	diffFormatsMu.RLock()
+	for name := range diffFormats {
+		names = append(names, name)
+	}
+	diffFormatsMu.RUnlock()
+	sort.Strings(names[1:])
+
+	var formats []*DiffFormats
+	seen := map[*DiffFormats]bool{}
+	for _, name := range names {
+		if f := diffFormatsOf(name); !seen[f] {
+			seen[f] = true
+			formats = append(formats, f)
+		}
+	}
+	return formats
+}
+
+// unitIndex 返回 unit 在 diffUnits 中的下标
+func unitIndex(unit Unit) int {
+	for i, u := range diffUnits {
+		if u == unit {
+			return i
+		}
+	}
+	return len(diffUnits)
+}
+
+// trimRelativeAffix 去掉表示方向的前缀、后缀，返回剩余部分及方向，没有方向时视为过去
+func trimRelativeAffix(s string, formats []*DiffFormats) (string, int) {
+	affixes := append([]relativeAffix{}, extraAffixes...)
+	for _, f := range formats {
+		for _, tpl := range []struct {
+			format string
+			sign   int
+		}{{f.Ago, -1}, {f.Before, -1}, {f.FromNow, 1}, {f.After, 1}} {
+			if i := strings.Index(tpl.format, "%s"); i >= 0 {
+				affixes = append(affixes, relativeAffix{strings.ToLower(tpl.format[:i]), strings.ToLower(tpl.format[i+2:]), tpl.sign})
+			}
+		}
+	}
+	// 优先匹配较长的写法，如 "之前" 优先于 "前"
+	sort.SliceStable(affixes, func(i, j int) bool {
+		return len(affixes[i].prefix)+len(affixes[i].suffix) > len(affixes[j].prefix)+len(affixes[j].suffix)
+	})
+	for _, a := range affixes {
+		if a.prefix == "" && a.suffix == "" {
+			continue
+		}
+		if len(s) > len(a.prefix)+len(a.suffix) && strings.HasPrefix(s, a.prefix) && strings.HasSuffix(s, a.suffix) {
+			return strings.TrimSpace(s[len(a.prefix) : len(s)-len(a.suffix)]), a.sign
+		}
+	}
+	return s, -1
+}
+
+// unitWords 返回所有可识别的单位写法，不同语言的写法相同时使用排在前面的语言
+func unitWords(formats []*DiffFormats) map[string]Unit {
+	words := make(map[string]Unit, len(extraUnitWords))
+	for w, u := range extraUnitWords {
+		words[w] = u
+	}
+	add := func(tpl string, unit Unit) {
+		w := strings.ToLower(strings.TrimSpace(strings.Replace(tpl, "%d", "", 1)))
+		if _, ok := words[w]; w != "" && !ok {
+			words[w] = unit
+		}
+	}
+	units := append(append([]Unit{}, diffUnits...), Millisecond, Microsecond, Nanosecond)
+	for _, f := range formats {
+		for _, unit := range units {
+			for _, tpl := range f.Units[unit] {
+				add(tpl, unit)
+			}
+			for _, tpl := range f.Plurals[unit] {
+				add(tpl, unit)
+			}
+			for _, tpl := range f.RelativePlurals[unit] {
+				add(tpl, unit)
+			}
+		}
+	}
+	return words
+}
+
+// diffSeparators 返回所有可识别的单位之间的分隔符，包括各语言 Join 时的分隔符，如 " und "、" y "
+func diffSeparators(formats []*DiffFormats) []string {
+	seps := append([]string{}, diffExtraSeparators...)
+	for _, f := range formats {
+		for _, sep := range []string{f.Separator, f.ListSeparator, f.LastSeparator} {
+			if sep = strings.ToLower(strings.TrimSpace(sep)); sep != "" {
+				seps = append(seps, sep)
+			}
+		}
+	}
+	// 优先去掉较长的分隔符
+	sort.SliceStable(seps, func(i, j int) bool { return len(seps[i]) > len(seps[j]) })
+	return seps
+}
+
+// diffPart 解析得到的数值及单位，数值可以是小数
+type diffPart struct {
+	value float64
+	unit  Unit
+}
+
+// wholeParts 将小数部分换算为更小的单位，如 1.5 天换算为 1 天 12 小时
+func wholeParts(parts []diffPart) []diffPart {
+	var whole []diffPart
+	for _, p := range parts {
+		for {
+			n := math.Floor(p.value + 1e-9)
+			fraction := p.value - n
+			sub, ok := subUnits[p.unit]
+			if n > 0 || fraction < 1e-9 || !ok {
+				whole = append(whole, diffPart{n, p.unit})
+			}
+			if fraction < 1e-9 || !ok {
+				break
+			}
+			p = diffPart{fraction * float64(sub.count), sub.unit}
+		}
+	}
+	return whole
+}
+
+// diffAmount 数值的写法，阿拉伯数字及中文数字可直接跟单位，英文数值与单位之间需有空格
+const diffAmount = `(?:(\d+(?:\.\d+)?|[零〇一二两三四五六七八九十百半]+)\s*|(half an?|half|an?|one|a few|few|several)\s+)`
+
+// parseDiffParts 解析如 "2 days, 3 hours and 4 minutes"、"2d 3h"、"2天3小时" 的时间差
+func parseDiffParts(s string, words map[string]Unit, seps []string) ([]diffPart, bool) {
+	keys := make([]string, 0, len(words))
+	for w := range words {
+		keys = append(keys, regexp.QuoteMeta(w))
+	}
+	sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+	re := regexp.MustCompile(diffAmount + `\s*(` + strings.Join(keys, "|") + `)`)
+
+	var parts []diffPart
+	pos := 0
+	for _, m := range re.FindAllStringSubmatchIndex(s, -1) {
+		if !isDiffSeparator(s[pos:m[0]], seps) {
+			return nil, false
+		}
+		// 单位后面不能紧跟字母，如 "3 dollars"，英文数值前面也不能紧跟字母
+		if r, _ := utf8.DecodeRuneInString(s[m[1]:]); isLetter(r) {
+			return nil, false
+		}
+		amount := m[2:4]
+		if amount[0] < 0 {
+			amount = m[4:6]
+			if r, _ := utf8.DecodeLastRuneInString(s[:m[0]]); isLetter(r) {
+				return nil, false
+			}
+		}
+		pos = m[1]
+		unit := words[s[m[6]:m[7]]]
+		value, ok := parseAmount(s[amount[0]:amount[1]])
+		if !ok {
+			return nil, false
+		}
+		parts = append(parts, diffPart{value, unit})
+	}
+	if len(parts) == 0 || !isDiffSeparator(s[pos:], seps) {
+		return nil, false
+	}
+	return parts, true
+}
+
+// isLetter 判断是否是英文字母
+func isLetter(r rune) bool {
+	return r >= 'a' && r <= 'z'
+}
+
+// isDiffSeparator 判断是否是各单位之间的分隔符
+func isDiffSeparator(s string, seps []string) bool {
+	s = strings.TrimSpace(s)
+	for _, sep := range seps {
+		s = strings.TrimSpace(strings.Replace(s, sep, "", -1))
+	}
+	return s == ""
+}
+
+// parseAmount 解析数值，如 "3"、"1.5"、"an"、"half"、"十五"、"两"、"半"
+func parseAmount(s string) (float64, bool) {
+	switch s {
+	case "a", "an", "one":
+		return 1, true
+	case "a few", "few", "several":
+		return 3, true
+	case "half", "half a", "half an", "半":
+		return 0.5, true
+	}
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return n, true
+	}
+	half := 0.0
+	if strings.HasSuffix(s, "半") {
+		half = 0.5
+	}
+	n, ok := parseChineseNumber(strings.TrimSuffix(s, "半"))
+	return float64(n) + half, ok
+}
+
+// chineseDigits 中文数字
+var chineseDigits = map[rune]int{'零': 0, '〇': 0, '一': 1, '二': 2, '两': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9}
+
+// parseChineseNumber 解析一百以内的中文数字，如 "十五"、"二十"、"两"
+func parseChineseNumber(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	total, cur := 0, 0
+	for _, r := range s {
+		switch r {
+		case '十':
+			if cur == 0 {
+				cur = 1
+			}
+			total += cur * 10
+			cur = 0
+		case '百':
+			if cur == 0 {
+				cur = 1
+			}
+			total += cur * 100
+			cur = 0
+		default:
+			d, ok := chineseDigits[r]
+			if !ok {
+				return 0, false
+			}
+			cur = d
+		}
+	}
+	return total + cur, true
+}
@@ -0,0 +1,79 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDiffForHumans(t *testing.T) {
+	as := assert.New(t)
+	ref := Create(2019, 4, 12, 8, 0, 0, time.UTC)
+	tests := []struct {
+		value     string
+		want      string
+		precision Unit
+	}{
+		{"3 hours ago", "2019-04-12 05:00:00", Hour},
+		{"an hour ago", "2019-04-12 07:00:00", Hour},
+		{"a minute from now", "2019-04-12 08:01:00", Minute},
+		{"2 days from now", "2019-04-14 08:00:00", Day},
+		{"in 3 days", "2019-04-15 08:00:00", Day},
+		{"1 year 2 months later", "2020-06-12 08:00:00", Month},
+		{"2 days, 3 hours and 40 minutes before", "2019-04-10 04:20:00", Minute},
+		{"2d 3h ago", "2019-04-10 05:00:00", Hour},
+		{"1mo", "2019-03-12 08:00:00", Month},
+		{"half an hour ago", "2019-04-12 07:30:00", Minute},
+		{"Just Now", "2019-04-12 08:00:00", Second},
+		{"yesterday", "2019-04-11 08:00:00", Day},
+		{"the day after tomorrow", "2019-04-14 08:00:00", Day},
+		{"5分钟前", "2019-04-12 07:55:00", Minute},
+		{"刚刚", "2019-04-12 08:00:00", Second},
+		{"三天后", "2019-04-15 08:00:00", Day},
+		{"两个小时之前", "2019-04-12 06:00:00", Hour},
+		{"半小时前", "2019-04-12 07:30:00", Minute},
+		{"1年3个月后", "2020-07-12 08:00:00", Month},
+		{"十五秒前", "2019-04-12 07:59:45", Second},
+		{"前天", "2019-04-10 08:00:00", Day},
+	}
+	for _, tt := range tests {
+		c, precision, err := ParseDiffForHumans(tt.value, ref)
+		as.Nil(err, tt.value)
+		as.Equal(tt.want, c.ToDateTimeString(), tt.value)
+		as.Equal(tt.precision, precision, tt.value)
+	}
+
+	for _, value := range []string{"", "ago", "3 dollars ago", "3 hours and then", "and", "很久以前"} {
+		_, _, err := ParseDiffForHumans(value, ref)
+		as.Equal(ErrDiffParse, err, value)
+	}
+}
+
+func TestParseDiffForHumansRoundTrip(t *testing.T) {
+	as := assert.New(t)
+	ref := Create(2019, 4, 12, 8, 0, 0, time.UTC)
+	others := []*Carbon{
+		Create(2019, 4, 12, 8, 0, 1, time.UTC),
+		Create(2019, 4, 14, 11, 40, 5, time.UTC),
+		Create(2019, 1, 31, 12, 0, 0, time.UTC),
+		Create(2021, 2, 28, 23, 59, 59, time.UTC),
+	}
+	// 所有已注册的语言
+	var options []*DiffOptions
+	for _, locale := range Locales() {
+		options = append(options,
+			&DiffOptions{Parts: 7, Locale: locale},
+			&DiffOptions{Parts: 7, Short: true, Locale: locale},
+			&DiffOptions{Parts: 7, Join: true, Locale: locale},
+		)
+	}
+	for _, other := range others {
+		for _, opts := range options {
+			s := other.DiffForHumansWithOptions(opts, ref)
+			c, _, err := ParseDiffForHumans(s, ref)
+			as.Nil(err, "%s: %s", opts.Locale, s)
+			as.Equal(other.ToDateTimeString(), c.ToDateTimeString(), "%s: %s", opts.Locale, s)
+		}
+	}
+}
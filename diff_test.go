@@ -0,0 +1,77 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarbon_DiffForHumansUnits(t *testing.T) {
+	as := assert.New(t)
+	base := Create(2019, 1, 31, 12, 0, 0, time.UTC)
+	tests := []struct {
+		other *Carbon
+		want  string
+	}{
+		{Create(2019, 1, 31, 12, 0, 0, time.UTC), "0 seconds before"},
+		{Create(2019, 1, 31, 12, 0, 1, time.UTC), "1 second before"},
+		{Create(2019, 1, 31, 12, 59, 59, time.UTC), "59 minutes before"},
+		{Create(2019, 2, 1, 11, 0, 0, time.UTC), "23 hours before"},
+		{Create(2019, 2, 7, 12, 0, 0, time.UTC), "1 week before"},
+		{Create(2019, 2, 27, 12, 0, 0, time.UTC), "3 weeks before"},
+		{Create(2019, 2, 28, 12, 0, 0, time.UTC), "1 month before"},
+		{Create(2019, 3, 31, 12, 0, 0, time.UTC), "2 months before"},
+		{Create(2020, 1, 31, 11, 0, 0, time.UTC), "11 months before"},
+		{Create(2021, 2, 1, 12, 0, 0, time.UTC), "2 years before"},
+		{Create(2018, 1, 31, 12, 0, 0, time.UTC), "1 year after"},
+	}
+	for _, tt := range tests {
+		as.Equal(tt.want, base.DiffForHumans(tt.other))
+	}
+	as.Equal("1 day ago", Now().SubDay().DiffForHumans())
+	as.Equal("2 hours from now", CreateFromGo(time.Now().Add(2*time.Hour+time.Second)).DiffForHumans())
+}
+
+func TestCarbon_DiffForHumansWithOptions(t *testing.T) {
+	as := assert.New(t)
+	base := Create(2019, 4, 12, 8, 0, 0, time.UTC)
+	other := Create(2019, 4, 14, 11, 40, 5, time.UTC)
+	tests := []struct {
+		opts *DiffOptions
+		want string
+	}{
+		{&DiffOptions{}, "2 days before"},
+		{&DiffOptions{Parts: 2}, "2 days 3 hours before"},
+		{&DiffOptions{Parts: 3, Join: true}, "2 days, 3 hours and 40 minutes before"},
+		{&DiffOptions{Parts: 2, Short: true, Absolute: true}, "2d 3h"},
+		{&DiffOptions{Parts: 2, Rounding: RoundingRound}, "2 days 4 hours before"},
+		{&DiffOptions{Rounding: RoundingCeil}, "3 days before"},
+		{&DiffOptions{Rounding: RoundingRound}, "2 days before"},
+		{&DiffOptions{JustNowThreshold: time.Hour * 72}, "just now"},
+	}
+	for _, tt := range tests {
+		as.Equal(tt.want, base.DiffForHumansWithOptions(tt.opts, other))
+	}
+
+	// 进位到更大的单位
+	as.Equal("1 year after", Create(2019, 12, 20, 0, 0, 0, time.UTC).
+		DiffForHumansWithOptions(&DiffOptions{Rounding: RoundingRound}, Create(2019, 1, 1, 0, 0, 0, time.UTC)))
+	as.Equal("1 year before", Create(2019, 1, 1, 0, 0, 0, time.UTC).
+		DiffForHumansWithOptions(&DiffOptions{Rounding: RoundingCeil}, Create(2019, 12, 2, 0, 0, 0, time.UTC)))
+	as.Equal("1 year 2 days before", Create(2019, 1, 1, 0, 0, 0, time.UTC).
+		DiffForHumansWithOptions(&DiffOptions{Parts: 4}, Create(2020, 1, 3, 0, 0, 0, time.UTC)))
+	// 闰年的 2 月 29 日
+	as.Equal("1 year before", Create(2020, 2, 29, 0, 0, 0, time.UTC).
+		DiffForHumansWithOptions(&DiffOptions{Parts: 3}, Create(2021, 2, 28, 0, 0, 0, time.UTC)))
+}
+
+func TestCarbon_DiffForHumansLocale(t *testing.T) {
+	as := assert.New(t)
+	base := Create(2019, 4, 12, 8, 0, 0, time.UTC)
+	other := Create(2019, 4, 14, 11, 40, 5, time.UTC)
+	as.Equal("2天前", base.DiffForHumansWithOptions(&DiffOptions{Locale: "zh-CN"}, other))
+	as.Equal("2天3小时40分钟后", other.DiffForHumansWithOptions(&DiffOptions{Locale: "zh-CN", Parts: 3}, base))
+	as.Equal("刚刚", base.DiffForHumansWithOptions(&DiffOptions{Locale: "zh-CN", JustNowThreshold: time.Hour * 72}, other))
+	as.Equal("2 days before", base.DiffForHumansWithOptions(&DiffOptions{Locale: "xx"}, other))
+}
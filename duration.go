@@ -0,0 +1,148 @@
+package carbon
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// DurationOptions FormatDuration 的选项
+type DurationOptions struct {
+	// Locale 语言，如 "en"、"zh-CN"，默认为 "en"
+	Locale string
+	// Short 使用单位简写，如 "1h 20m 5s"
+	Short bool
+	// Join 使用逗号和 and 连接各个单位，如 "1 hour, 20 minutes and 5 seconds"
+	Join bool
+	// Separator 各单位之间的分隔符，为空时使用语言默认的分隔符
+	Separator string
+	// MaxUnit 最大单位，可选 Week 至 Nanosecond，默认为 Day
+	MaxUnit Unit
+	// MinUnit 最小单位，可选 Week 至 Nanosecond，默认为 Second
+	MinUnit Unit
+	// Parts 最多输出的单位个数，为 0 时不限制
+	Parts int
+	// Rounding 最后一个单位的取整方式，默认向下取整
+	Rounding Rounding
+}
+
+// durationUnits FormatDuration 使用的单位，从大到小排列
+var durationUnits = []Unit{Week, Day, Hour, Minute, Second, Millisecond, Microsecond, Nanosecond}
+
+// unitDurations 各单位的时长，年、月按 365 天、30 天计算
+var unitDurations = map[Unit]time.Duration{
+	Year:        365 * 24 * time.Hour,
+	Month:       30 * 24 * time.Hour,
+	Week:        7 * 24 * time.Hour,
+	Day:         24 * time.Hour,
+	Hour:        time.Hour,
+	Minute:      time.Minute,
+	Second:      time.Second,
+	Millisecond: time.Millisecond,
+	Microsecond: time.Microsecond,
+	Nanosecond:  time.Nanosecond,
+}
+
+// durationUnitIndex 返回 unit 在 durationUnits 中的下标，不存在时返回 def
+func durationUnitIndex(unit Unit, def int) int {
+	for i, u := range durationUnits {
+		if u == unit {
+			return i
+		}
+	}
+	return def
+}
+
+// splitDuration 将 d 拆分为 units 中的各单位
+func splitDuration(d uint64, units []Unit) []int {
+	values := make([]int, len(units))
+	for i, unit := range units {
+		size := uint64(unitDurations[unit])
+		values[i] = int(d / size)
+		d %= size
+	}
+	return values
+}
+
+// FormatDuration 返回人类可读的时长，如 "1h 20m 5s"、"1 hour, 20 minutes"、"1小时20分钟5秒"
+func FormatDuration(d time.Duration, opts *DurationOptions) string {
+	if opts == nil {
+		opts = &DurationOptions{}
+	}
+	f := diffFormatsOf(opts.Locale)
+	if opts.Separator != "" {
+		sep := *f
+		sep.Separator, sep.ListSeparator = opts.Separator, opts.Separator
+		f = &sep
+	}
+
+	max := durationUnitIndex(opts.MaxUnit, 1)
+	min := durationUnitIndex(opts.MinUnit, 4)
+	if min < max {
+		min = max
+	}
+	units := durationUnits[max : min+1]
+	parts := opts.Parts
+	if parts < 1 {
+		parts = len(units)
+	}
+
+	sign, u := "", uint64(d)
+	if d < 0 {
+		sign, u = "-", uint64(-(d+1))+1
+	}
+	values := splitDuration(u, units)
+	first, last := selectParts(values, parts)
+	if size := uint64(unitDurations[units[last]]); u%size > 0 {
+		rest := u % size
+		if opts.Rounding == RoundingCeil || opts.Rounding == RoundingRound && rest*2 >= size {
+			// 进位后重新计算，超出的部分会自动进到更大的单位
+			u += size - rest
+			values = splitDuration(u, units)
+			first, last = selectParts(values, parts)
+		}
+	}
+
+	var items []string
+	for i := first; i <= last; i++ {
+		if values[i] == 0 && i != first {
+			continue
+		}
+		items = append(items, f.formatUnit(values[i], units[i], opts.Short))
+	}
+	if len(items) == 1 && values[first] == 0 {
+		sign = ""
+	}
+	return sign + f.join(items, opts.Join)
+}
+
+// ParseHumanDuration 解析人类可读的时长，如 "1 day 2 hours"、"1d2h"、"1.5h"、"3天"、"1小时20分钟"，
+// 支持 time.ParseDuration 的格式及所有已注册语言的单位，年、月按 365 天、30 天计算
+func ParseHumanDuration(value string) (time.Duration, error) {
+	s := strings.TrimSpace(value)
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+
+	s = strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	sign := 1.0
+	if strings.HasPrefix(s, "-") {
+		sign, s = -1, s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	formats := allDiffFormats()
+	parts, ok := parseDiffParts(strings.TrimSpace(s), unitWords(formats), diffSeparators(formats))
+	if !ok {
+		return 0, ErrDurationParse
+	}
+
+	total := 0.0
+	for _, p := range parts {
+		total += p.value * float64(unitDurations[p.unit])
+	}
+	if total >= math.MaxInt64 {
+		return 0, ErrDurationParse
+	}
+	return time.Duration(sign * math.Round(total)), nil
+}
@@ -0,0 +1,87 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDuration(t *testing.T) {
+	as := assert.New(t)
+	d := time.Hour + 20*time.Minute + 5*time.Second + 300*time.Millisecond
+	tests := []struct {
+		d    time.Duration
+		opts *DurationOptions
+		want string
+	}{
+		{d, &DurationOptions{Short: true}, "1h 20m 5s"},
+		{d, nil, "1 hour 20 minutes 5 seconds"},
+		{d, &DurationOptions{Parts: 2, Separator: ", "}, "1 hour, 20 minutes"},
+		{d, &DurationOptions{Join: true}, "1 hour, 20 minutes and 5 seconds"},
+		{d, &DurationOptions{Locale: "zh-CN"}, "1小时20分钟5秒"},
+		{d, &DurationOptions{Short: true, MinUnit: Millisecond}, "1h 20m 5s 300ms"},
+		{d, &DurationOptions{Short: true, MaxUnit: Minute}, "80m 5s"},
+		{d, &DurationOptions{Short: true, MaxUnit: Second}, "4805s"},
+		{d, &DurationOptions{Short: true, MinUnit: Minute}, "1h 20m"},
+		{d, &DurationOptions{Short: true, Parts: 1, Rounding: RoundingRound}, "1h"},
+		{d, &DurationOptions{Short: true, Parts: 1, Rounding: RoundingCeil}, "2h"},
+		{59*time.Minute + 40*time.Second, &DurationOptions{Short: true, MinUnit: Minute, Rounding: RoundingRound}, "1h"},
+		{-d, &DurationOptions{Short: true, Parts: 2}, "-1h 20m"},
+		{0, nil, "0 seconds"},
+		{time.Second, nil, "1 second"},
+		{500 * time.Millisecond, &DurationOptions{Short: true}, "0s"},
+		{-500 * time.Millisecond, &DurationOptions{Short: true}, "0s"},
+		{1500 * time.Microsecond, &DurationOptions{Short: true, MinUnit: Nanosecond}, "1ms 500µs"},
+		{10*24*time.Hour + time.Hour, &DurationOptions{}, "10 days 1 hour"},
+		{10*24*time.Hour + time.Hour, &DurationOptions{MaxUnit: Week}, "1 week 3 days 1 hour"},
+		{26 * time.Hour, &DurationOptions{MaxUnit: Year}, "1 day 2 hours"},
+		{time.Duration(-1 << 63), &DurationOptions{Short: true, Parts: 1}, "-106751d"},
+	}
+	for _, tt := range tests {
+		as.Equal(tt.want, FormatDuration(tt.d, tt.opts))
+	}
+}
+
+func TestParseHumanDuration(t *testing.T) {
+	as := assert.New(t)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"1h20m5s", time.Hour + 20*time.Minute + 5*time.Second},
+		{"1h 20m 5s", time.Hour + 20*time.Minute + 5*time.Second},
+		{"1 hour, 20 minutes and 5 seconds", time.Hour + 20*time.Minute + 5*time.Second},
+		{"1 day 2 hours", 26 * time.Hour},
+		{"1d2h", 26 * time.Hour},
+		{"1.5 days", 36 * time.Hour},
+		{"2 weeks", 14 * 24 * time.Hour},
+		{"1 year", 365 * 24 * time.Hour},
+		{"-1d", -24 * time.Hour},
+		{"300ms", 300 * time.Millisecond},
+		{"3天", 72 * time.Hour},
+		{"1小时20分钟5秒", time.Hour + 20*time.Minute + 5*time.Second},
+		{"半小时", 30 * time.Minute},
+		{"两个星期", 14 * 24 * time.Hour},
+		{"an hour", time.Hour},
+	}
+	for _, tt := range tests {
+		d, err := ParseHumanDuration(tt.value)
+		as.Nil(err, tt.value)
+		as.Equal(tt.want, d, tt.value)
+	}
+
+	for _, value := range []string{"", "abc", "3 dollars", "1 day ago", "100000000 years"} {
+		_, err := ParseHumanDuration(value)
+		as.Equal(ErrDurationParse, err, value)
+	}
+
+	// 格式化后可以解析回来
+	d := 3*24*time.Hour + 4*time.Hour + 5*time.Minute + 6*time.Second
+	for _, opts := range []*DurationOptions{{}, {Short: true}, {Join: true}, {Locale: "zh-CN"}, {MaxUnit: Week}} {
+		s := FormatDuration(d, opts)
+		parsed, err := ParseHumanDuration(s)
+		as.Nil(err, s)
+		as.Equal(d, parsed, s)
+	}
+}
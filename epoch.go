@@ -0,0 +1,183 @@
+package carbon
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// jdMJD 简化儒略日的起点 1858-11-17 00:00:00 UTC 的儒略日
+	jdMJD = 2400000.5
+	// fileTimeUnixEpoch 1601-01-01 到 1970-01-01 的 100 纳秒数
+	fileTimeUnixEpoch = 116444736000000000
+	// ntpUnixEpoch 1900-01-01 到 1970-01-01 的秒数
+	ntpUnixEpoch = 2208988800
+	// gpsUnixEpoch GPS 起点 1980-01-06 00:00:00 UTC 的时间戳
+	gpsUnixEpoch = 315964800
+	// cocoaUnixEpoch Cocoa 参考日期 2001-01-01 00:00:00 UTC 的时间戳
+	cocoaUnixEpoch = 978307200
+	// secondsPerWeek 一周的秒数
+	secondsPerWeek = 7 * 86400
+)
+
+// gpsLeapSeconds 闰秒表，自 utc 时间戳起 GPS 时间比 UTC 快 offset 秒
+var gpsLeapSeconds = []struct {
+	utc    int64
+	offset int64
+}{
+	{362793600, 1},   // 1981-07-01
+	{394329600, 2},   // 1982-07-01
+	{425865600, 3},   // 1983-07-01
+	{489024000, 4},   // 1985-07-01
+	{567993600, 5},   // 1988-01-01
+	{631152000, 6},   // 1990-01-01
+	{662688000, 7},   // 1991-01-01
+	{709948800, 8},   // 1992-07-01
+	{741484800, 9},   // 1993-07-01
+	{773020800, 10},  // 1994-07-01
+	{820454400, 11},  // 1996-01-01
+	{867715200, 12},  // 1997-07-01
+	{915148800, 13},  // 1999-01-01
+	{1136073600, 14}, // 2006-01-01
+	{1230768000, 15}, // 2009-01-01
+	{1341100800, 16}, // 2012-07-01
+	{1435708800, 17}, // 2015-07-01
+	{1483228800, 18}, // 2017-01-01
+}
+
+// unixFloat 将带小数的时间戳转换为时间，按 precision 四舍五入以消除浮点数误差
+func unixFloat(seconds float64, precision time.Duration) time.Time {
+	sec := math.Floor(seconds)
+	nsec := math.Round((seconds-sec)*float64(time.Second)/float64(precision)) * float64(precision)
+	return time.Unix(int64(sec), int64(nsec))
+}
+
+// floatUnix 返回带小数的时间戳
+func floatUnix(t time.Time) float64 {
+	return float64(t.Unix()) + float64(t.Nanosecond())/float64(time.Second)
+}
+
+// CreateFromExcelSerial 从 Excel 日期序号中解析 tz 时区的时间，如 43567.5 为 2019-04-12 12:00:00，精确到毫秒。
+// 默认为 1900 日期系统，兼容 Excel 将 1900 年视为闰年的错误，序号 60 即不存在的 1900-02-29 返回错误；
+// date1904 为 true 时使用 Mac 的 1904 日期系统
+func CreateFromExcelSerial(serial float64, tz *time.Location, date1904 ...bool) (*Carbon, error) {
+	if serial < 0 || math.IsNaN(serial) || math.IsInf(serial, 0) {
+		return &Carbon{}, ErrExcelSerial
+	}
+	days := math.Floor(serial)
+	msec := int(math.Round((serial - days) * 86400e3))
+	switch {
+	case len(date1904) > 0 && date1904[0]:
+		return CreateFromGo(time.Date(1904, 1, 1+int(days), 0, 0, 0, msec*1e6, tz)), nil
+	case days == 60:
+		return &Carbon{}, ErrExcelSerial
+	case days < 60:
+		return CreateFromGo(time.Date(1899, 12, 31+int(days), 0, 0, 0, msec*1e6, tz)), nil
+	}
+	return CreateFromGo(time.Date(1899, 12, 30+int(days), 0, 0, 0, msec*1e6, tz)), nil
+}
+
+// ToExcelSerial 返回 Excel 日期序号，按当前时区的日期时间计算，精确到毫秒。
+// 默认为 1900 日期系统，1900-03-01 之前的日期与 Excel 一致少算一天；date1904 为 true 时使用 1904 日期系统
+func (c *Carbon) ToExcelSerial(date1904 ...bool) float64 {
+	y, m, d := c.time.Date()
+	wall := time.Date(y, m, d, c.time.Hour(), c.time.Minute(), c.time.Second(), c.time.Nanosecond(), time.UTC)
+	base := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	if len(date1904) > 0 && date1904[0] {
+		base = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	} else if wall.Before(time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		base = time.Date(1899, 12, 31, 0, 0, 0, 0, time.UTC)
+	}
+	msec := wall.Sub(base).Round(time.Millisecond) / time.Millisecond
+	return float64(msec) / 86400e3
+}
+
+// CreateFromJulianDay 从儒略日中解析时间，如 2458585.5 为 2019-04-12 00:00:00 UTC，精确到毫秒
+func CreateFromJulianDay(jd float64) *Carbon {
+	return CreateFromGo(unixFloat((jd-jdUnixEpoch)*86400, time.Millisecond))
+}
+
+// CreateFromModifiedJulianDay 从简化儒略日中解析时间，如 58585 为 2019-04-12 00:00:00 UTC，精确到毫秒
+func CreateFromModifiedJulianDay(mjd float64) *Carbon {
+	return CreateFromJulianDay(mjd + jdMJD)
+}
+
+// JulianDay 返回儒略日，即自公元前 4713 年 1 月 1 日 UTC 正午起的天数
+func (c *Carbon) JulianDay() float64 {
+	return jdUnixEpoch + floatUnix(c.time)/86400
+}
+
+// ModifiedJulianDay 返回简化儒略日，即自 1858-11-17 00:00:00 UTC 起的天数
+func (c *Carbon) ModifiedJulianDay() float64 {
+	return float64(c.time.Unix()-(jdMJD-jdUnixEpoch)*86400)/86400 + float64(c.time.Nanosecond())/86400e9
+}
+
+// CreateFromFileTime 从 Windows FILETIME 中解析时间，即自 1601-01-01 00:00:00 UTC 起的 100 纳秒数
+func CreateFromFileTime(ft int64) *Carbon {
+	ft -= fileTimeUnixEpoch
+	return CreateFromGo(time.Unix(ft/1e7, ft%1e7*100))
+}
+
+// ToFileTime 返回 Windows FILETIME，即自 1601-01-01 00:00:00 UTC 起的 100 纳秒数
+func (c *Carbon) ToFileTime() int64 {
+	return c.time.Unix()*1e7 + int64(c.time.Nanosecond())/100 + fileTimeUnixEpoch
+}
+
+// CreateFromNTPTime 从 NTP 64 位时间戳中解析时间，高 32 位为自 1900-01-01 UTC 起的秒数，低 32 位为秒的小数部分。
+// 与 RFC 4330 一致，秒数最高位为 0 时视为 2036-02-07 之后的下一个周期
+func CreateFromNTPTime(ts uint64) *Carbon {
+	sec := int64(ts >> 32)
+	if sec < 1<<31 {
+		sec += 1 << 32
+	}
+	nsec := int64((ts & 0xffffffff) * uint64(time.Second) >> 32)
+	return CreateFromGo(time.Unix(sec-ntpUnixEpoch, nsec))
+}
+
+// ToNTPTime 返回 NTP 64 位时间戳，仅支持 1968-01-20 到 2104-02-26 之间的时间
+func (c *Carbon) ToNTPTime() uint64 {
+	sec := uint64(c.time.Unix()+ntpUnixEpoch) & 0xffffffff
+	// 小数部分向上取整，保证转换回来的纳秒数不变
+	frac := (uint64(c.time.Nanosecond())<<32 + uint64(time.Second) - 1) / uint64(time.Second)
+	return sec<<32 | frac
+}
+
+// gpsOffset 返回 UTC 时间戳 utc 时 GPS 时间与 UTC 相差的闰秒数
+func gpsOffset(utc int64) int64 {
+	offset := int64(0)
+	for _, leap := range gpsLeapSeconds {
+		if utc >= leap.utc {
+			offset = leap.offset
+		}
+	}
+	return offset
+}
+
+// CreateFromGPSTime 从 GPS 周数及周内秒数中解析时间，GPS 时间不含闰秒，按闰秒表换算为 UTC，精确到微秒
+func CreateFromGPSTime(week int, seconds float64) *Carbon {
+	gps := unixFloat(float64(gpsUnixEpoch)+float64(week)*secondsPerWeek+seconds, time.Microsecond)
+	offset := int64(0)
+	for _, leap := range gpsLeapSeconds {
+		if gps.Unix() >= leap.utc+leap.offset {
+			offset = leap.offset
+		}
+	}
+	return CreateFromGo(gps.Add(-time.Duration(offset) * time.Second))
+}
+
+// ToGPSTime 返回 GPS 周数及周内秒数，GPS 时间比 UTC 快 1980 年以来的闰秒数，如 2017 年起快 18 秒
+func (c *Carbon) ToGPSTime() (week int, seconds float64) {
+	elapsed := c.time.Unix() - gpsUnixEpoch + gpsOffset(c.time.Unix())
+	week = int(math.Floor(float64(elapsed) / secondsPerWeek))
+	return week, float64(elapsed-int64(week)*secondsPerWeek) + float64(c.time.Nanosecond())/float64(time.Second)
+}
+
+// CreateFromCocoaTime 从 Apple Cocoa 时间中解析时间，即 NSDate 自 2001-01-01 00:00:00 UTC 起的秒数，精确到微秒
+func CreateFromCocoaTime(seconds float64) *Carbon {
+	return CreateFromGo(unixFloat(seconds+cocoaUnixEpoch, time.Microsecond))
+}
+
+// ToCocoaTime 返回 Apple Cocoa 时间，即 NSDate 自 2001-01-01 00:00:00 UTC 起的秒数
+func (c *Carbon) ToCocoaTime() float64 {
+	return float64(c.time.Unix()-cocoaUnixEpoch) + float64(c.time.Nanosecond())/float64(time.Second)
+}
@@ -0,0 +1,112 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateFromExcelSerial(t *testing.T) {
+	as := assert.New(t)
+	tz, _ := time.LoadLocation("Asia/Shanghai")
+	tests := []struct {
+		serial   float64
+		date1904 bool
+		want     string
+	}{
+		{43567, false, "2019-04-12 00:00:00"},
+		{43567.5, false, "2019-04-12 12:00:00"},
+		{43567.75, false, "2019-04-12 18:00:00"},
+		{1, false, "1900-01-01 00:00:00"},
+		{59, false, "1900-02-28 00:00:00"},
+		{61, false, "1900-03-01 00:00:00"},
+		{42105, true, "2019-04-12 00:00:00"},
+		{0, true, "1904-01-01 00:00:00"},
+	}
+	for _, test := range tests {
+		c, err := CreateFromExcelSerial(test.serial, tz, test.date1904)
+		as.Nil(err)
+		as.Equal(test.want, c.ToDateTimeString(), test.serial)
+		as.Equal(test.serial, c.ToExcelSerial(test.date1904), test.want)
+	}
+
+	c, err := CreateFromExcelSerial(43567.000011574, time.UTC)
+	as.Nil(err)
+	as.Equal("2019-04-12 00:00:01", c.ToDateTimeString())
+	as.Equal(43567.0, Create(2019, 4, 12, 0, 0, 0, tz).ToExcelSerial())
+
+	for _, serial := range []float64{60, 60.5, -1} {
+		_, err := CreateFromExcelSerial(serial, tz)
+		as.Equal(ErrExcelSerial, err, serial)
+	}
+}
+
+func TestCarbon_JulianDay(t *testing.T) {
+	as := assert.New(t)
+	c := CreateFromGo(time.Date(2019, 4, 12, 0, 0, 0, 0, time.UTC))
+	as.Equal(2458585.5, c.JulianDay())
+	as.Equal(58585.0, c.ModifiedJulianDay())
+	as.Equal(2451545.0, CreateFromGo(time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)).JulianDay())
+	as.Equal(0.25, CreateFromGo(time.Date(1858, 11, 17, 6, 0, 0, 0, time.UTC)).ModifiedJulianDay())
+
+	as.True(CreateFromJulianDay(2458585.5).time.Equal(c.time))
+	as.True(CreateFromJulianDay(2458586).time.Equal(c.time.Add(12 * time.Hour)))
+	as.True(CreateFromModifiedJulianDay(58585.125).time.Equal(c.time.Add(3 * time.Hour)))
+	as.True(CreateFromJulianDay(0).time.Equal(time.Date(-4713, 11, 24, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestCarbon_FileTime(t *testing.T) {
+	as := assert.New(t)
+	c := CreateFromGo(time.Date(2019, 4, 12, 0, 0, 0, 0, time.UTC))
+	as.Equal(int64(131995008000000000), c.ToFileTime())
+	as.True(CreateFromFileTime(131995008000000000).time.Equal(c.time))
+	as.True(CreateFromFileTime(116444736000000000).time.Equal(time.Unix(0, 0)))
+	as.True(CreateFromFileTime(0).time.Equal(time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC)))
+	as.True(CreateFromFileTime(131995008000000123).time.Equal(c.time.Add(12300)))
+}
+
+func TestCarbon_NTPTime(t *testing.T) {
+	as := assert.New(t)
+	c := CreateFromGo(time.Date(2019, 4, 12, 0, 0, 0, 0, time.UTC))
+	as.Equal(uint64(16166325621620736000), c.ToNTPTime())
+	as.True(CreateFromNTPTime(16166325621620736000).time.Equal(c.time))
+	as.True(CreateFromNTPTime(16166325621620736000 | 1<<31).time.Equal(c.time.Add(500 * time.Millisecond)))
+	// 秒数回绕后为下一个周期
+	as.True(CreateFromNTPTime(0).time.Equal(time.Date(2036, 2, 7, 6, 28, 16, 0, time.UTC)))
+	as.Equal(uint64(0), CreateFromGo(time.Date(2036, 2, 7, 6, 28, 16, 0, time.UTC)).ToNTPTime())
+
+	for _, nsec := range []int{1, 123456789, 999999999} {
+		c := CreateFromGo(time.Date(2019, 4, 12, 0, 0, 0, nsec, time.UTC))
+		as.True(CreateFromNTPTime(c.ToNTPTime()).time.Equal(c.time), nsec)
+	}
+}
+
+func TestCarbon_GPSTime(t *testing.T) {
+	as := assert.New(t)
+	tests := []struct {
+		utc     time.Time
+		week    int
+		seconds float64
+	}{
+		{time.Date(1980, 1, 6, 0, 0, 0, 0, time.UTC), 0, 0},
+		{time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), 1930, 18},
+		{time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC), 1930, 16},
+		{time.Date(2019, 4, 12, 15, 4, 5, 500000000, time.UTC), 2048, 5*86400 + 15*3600 + 4*60 + 5.5 + 18},
+	}
+	for _, test := range tests {
+		week, seconds := CreateFromGo(test.utc).ToGPSTime()
+		as.Equal(test.week, week, test.utc.String())
+		as.Equal(test.seconds, seconds, test.utc.String())
+		as.True(CreateFromGPSTime(test.week, test.seconds).time.Equal(test.utc), test.utc.String())
+	}
+}
+
+func TestCarbon_CocoaTime(t *testing.T) {
+	as := assert.New(t)
+	c := CreateFromGo(time.Date(2019, 4, 12, 0, 0, 0, 0, time.UTC))
+	as.Equal(576720000.0, c.ToCocoaTime())
+	as.True(CreateFromCocoaTime(576720000.25).time.Equal(c.time.Add(250 * time.Millisecond)))
+	as.True(CreateFromCocoaTime(0).time.Equal(time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)))
+	as.True(CreateFromCocoaTime(-86400).time.Equal(time.Date(2000, 12, 31, 0, 0, 0, 0, time.UTC)))
+}
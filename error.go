@@ -7,4 +7,34 @@ var (
 	ErrTimeParse = errors.New("parse time error")
 	//ErrTimestampParse 解析时间戳错误
 	ErrTimestampParse = errors.New("parse timestamp error")
+	//ErrLunarOutOfRange 农历年份超出支持范围
+	ErrLunarOutOfRange = errors.New("lunar year out of range")
+	//ErrLunarDate 农历日期错误
+	ErrLunarDate = errors.New("invalid lunar date")
+	//ErrSolarTermName 节气名称错误
+	ErrSolarTermName = errors.New("invalid solar term name")
+	//ErrFestivalName 节日未注册
+	ErrFestivalName = errors.New("unknown festival")
+	//ErrFestivalDate 当年没有该节日
+	ErrFestivalDate = errors.New("festival does not occur in this year")
+	//ErrCalendarDate 历法日期错误
+	ErrCalendarDate = errors.New("invalid calendar date")
+	//ErrCalendarEra 历法纪元错误
+	ErrCalendarEra = errors.New("invalid calendar era")
+	//ErrDiffParse 解析相对时间错误
+	ErrDiffParse = errors.New("parse diff for humans error")
+	//ErrDurationParse 解析时长错误
+	ErrDurationParse = errors.New("parse human duration error")
+	//ErrExcelSerial Excel 日期序号错误
+	ErrExcelSerial = errors.New("invalid excel serial")
+	//ErrIDParse 解析 ID 错误
+	ErrIDParse = errors.New("parse id error")
+	//ErrUUIDVersion UUID 版本不含时间
+	ErrUUIDVersion = errors.New("unsupported uuid version")
+	//ErrCronParse 解析 cron 表达式错误
+	ErrCronParse = errors.New("parse cron expression error")
+	//ErrRRuleParse 解析 RRULE 错误
+	ErrRRuleParse = errors.New("parse rrule error")
+	//ErrICalParse 解析 iCalendar 错误
+	ErrICalParse = errors.New("parse icalendar error")
 )
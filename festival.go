@@ -0,0 +1,171 @@
+package carbon
+
+import (
+	"sync"
+	"time"
+)
+
+// FestivalFunc 返回 year 年节日对应的公历日期，当年没有该节日时返回 nil
+type FestivalFunc func(year int, tz *time.Location) *Carbon
+
+type festival struct {
+	name string
+	date FestivalFunc
+}
+
+var (
+	festivalMu sync.RWMutex
+	festivals  []festival
+)
+
+func init() {
+	RegisterFestival("春节", LunarFestival(1, 1))
+	RegisterFestival("元宵节", LunarFestival(1, 15))
+	RegisterFestival("清明节", SolarTermFestival("清明"))
+	RegisterFestival("端午节", LunarFestival(5, 5))
+	RegisterFestival("七夕节", LunarFestival(7, 7))
+	RegisterFestival("中秋节", LunarFestival(8, 15))
+	RegisterFestival("重阳节", LunarFestival(9, 9))
+	RegisterFestival("腊八节", func(year int, tz *time.Location) *Carbon {
+		return lunarDate(year-1, 12, 8, tz)
+	})
+	RegisterFestival("除夕", func(year int, tz *time.Location) *Carbon {
+		newYear := lunarDate(year, 1, 1, tz)
+		if newYear == nil {
+			return nil
+		}
+		return CreateFromGo(newYear.time.AddDate(0, 0, -1))
+	})
+}
+
+// RegisterFestival 注册节日，同名的节日会被替换
+func RegisterFestival(name string, date FestivalFunc) {
+	festivalMu.Lock()
+	defer festivalMu.Unlock()
+	for i, f := range festivals {
+		if f.name == name {
+			festivals[i].date = date
+			return
+		}
+	}
+	festivals = append(festivals, festival{name: name, date: date})
+}
+
+// UnregisterFestival 移除已注册的节日
+func UnregisterFestival(name string) {
+	festivalMu.Lock()
+	defer festivalMu.Unlock()
+	for i, f := range festivals {
+		if f.name == name {
+			festivals = append(festivals[:i], festivals[i+1:]...)
+			return
+		}
+	}
+}
+
+// registeredFestivals 返回已注册节日的副本，调用 FestivalFunc 时不持有锁，节日函数中可以再注册或查询节日
+func registeredFestivals() []festival {
+	festivalMu.RLock()
+	defer festivalMu.RUnlock()
+	return append([]festival(nil), festivals...)
+}
+
+// SolarFestival 返回公历固定日期的节日，如元旦 SolarFestival(1, 1)
+func SolarFestival(month, day int) FestivalFunc {
+	return func(year int, tz *time.Location) *Carbon {
+		return Create(year, month, day, 0, 0, 0, tz)
+	}
+}
+
+// LunarFestival 返回农历固定日期的节日，如中秋节 LunarFestival(8, 15)
+func LunarFestival(month, day int) FestivalFunc {
+	return func(year int, tz *time.Location) *Carbon {
+		return lunarDate(year, month, day, tz)
+	}
+}
+
+// SolarTermFestival 返回以节气为日期的节日，如清明节 SolarTermFestival("清明")
+func SolarTermFestival(name string) FestivalFunc {
+	return func(year int, tz *time.Location) *Carbon {
+		term, err := CreateFromSolarTerm(year, name, tz)
+		if err != nil {
+			return nil
+		}
+		return Create(term.time.Year(), int(term.time.Month()), term.time.Day(), 0, 0, 0, tz)
+	}
+}
+
+// Easter 返回 year 年复活节的日期(格里高利历)
+func Easter(year int, tz *time.Location) *Carbon {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return Create(year, month, day, 0, 0, 0, tz)
+}
+
+// lunarDate 返回农历日期对应公历日期的零时，超出范围返回 nil
+func lunarDate(year, month, day int, tz *time.Location) *Carbon {
+	c, err := CreateFromLunar(year, month, day, false, tz)
+	if err != nil {
+		return nil
+	}
+	return Create(c.time.Year(), int(c.time.Month()), c.time.Day(), 0, 0, 0, tz)
+}
+
+// FestivalDate 返回 year 年名为 name 的节日的公历日期(零时)，tz 默认为 time.Local
+// 腊八节和除夕指 year 年春节之前的那一个
+func FestivalDate(name string, year int, tz ...*time.Location) (*Carbon, error) {
+	loc := time.Local
+	if len(tz) > 0 {
+		loc = tz[0]
+	}
+	for _, f := range registeredFestivals() {
+		if f.name == name {
+			if c := f.date(year, loc); c != nil {
+				return c, nil
+			}
+			return &Carbon{}, ErrFestivalDate
+		}
+	}
+	return &Carbon{}, ErrFestivalName
+}
+
+// Festivals 返回当天的所有节日，按注册顺序排列
+func (c *Carbon) Festivals() []string {
+	y, m, d := c.time.Date()
+	loc := c.time.Location()
+	var names []string
+	for _, f := range registeredFestivals() {
+		// 农历节日可能落在相邻的公历年份
+		for year := y - 1; year <= y+1; year++ {
+			date := f.date(year, loc)
+			if date == nil {
+				continue
+			}
+			if dy, dm, dd := date.time.Date(); dy == y && dm == m && dd == d {
+				names = append(names, f.name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// Festival 返回当天的节日，没有则返回空字符串
+func (c *Carbon) Festival() string {
+	if names := c.Festivals(); len(names) > 0 {
+		return names[0]
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFestivalDate(t *testing.T) {
+	as := assert.New(t)
+	shanghai := time.FixedZone("CST", 8*3600)
+	tests := []struct {
+		name string
+		year int
+		want string
+	}{
+		{"春节", 2019, "2019-02-05"},
+		{"元宵节", 2019, "2019-02-19"},
+		{"清明节", 2019, "2019-04-05"},
+		{"端午节", 2019, "2019-06-07"},
+		{"七夕节", 2019, "2019-08-07"},
+		{"中秋节", 2019, "2019-09-13"},
+		{"重阳节", 2019, "2019-10-07"},
+		{"腊八节", 2019, "2019-01-13"},
+		{"除夕", 2019, "2019-02-04"},
+		{"除夕", 2020, "2020-01-24"},
+		{"腊八节", 2023, "2022-12-30"},
+		{"中秋节", 2020, "2020-10-01"},
+	}
+	for _, tt := range tests {
+		c, err := FestivalDate(tt.name, tt.year, shanghai)
+		as.NoError(err)
+		as.Equal(tt.want, c.ToDateString(), "%s %d", tt.name, tt.year)
+		as.Equal(0, c.Hour)
+	}
+
+	_, err := FestivalDate("圣诞节", 2019)
+	as.Equal(ErrFestivalName, err)
+	_, err = FestivalDate("中秋节", 2200)
+	as.Equal(ErrFestivalDate, err)
+}
+
+func TestCarbon_Festival(t *testing.T) {
+	as := assert.New(t)
+	shanghai := time.FixedZone("CST", 8*3600)
+
+	as.Equal("中秋节", Create(2019, 9, 13, 20, 0, 0, shanghai).Festival())
+	as.Equal("除夕", Create(2020, 1, 24, 20, 0, 0, shanghai).Festival())
+	as.Equal("腊八节", Create(2022, 12, 30, 8, 0, 0, shanghai).Festival())
+	as.Equal("", Create(2019, 9, 14, 8, 0, 0, shanghai).Festival())
+	as.Empty(Create(2019, 9, 14, 8, 0, 0, shanghai).Festivals())
+}
+
+func TestRegisterFestival(t *testing.T) {
+	as := assert.New(t)
+	RegisterFestival("国庆节", SolarFestival(10, 1))
+	RegisterFestival("复活节", Easter)
+	RegisterFestival("感恩节", func(year int, tz *time.Location) *Carbon {
+		// 11 月第四个星期四
+		first := time.Date(year, 11, 1, 0, 0, 0, 0, tz)
+		offset := (int(time.Thursday) - int(first.Weekday()) + 7) % 7
+		return CreateFromGo(first.AddDate(0, 0, offset+21))
+	})
+	defer UnregisterFestival("国庆节")
+	defer UnregisterFestival("复活节")
+	defer UnregisterFestival("感恩节")
+
+	as.Equal([]string{"中秋节", "国庆节"}, Create(2020, 10, 1, 8, 0, 0, time.Local).Festivals())
+
+	for year, want := range map[int]string{2019: "2019-04-21", 2020: "2020-04-12", 2024: "2024-03-31", 2038: "2038-04-25"} {
+		c, err := FestivalDate("复活节", year)
+		as.NoError(err)
+		as.Equal(want, c.ToDateString())
+	}
+	c, err := FestivalDate("感恩节", 2019)
+	as.NoError(err)
+	as.Equal("2019-11-28", c.ToDateString())
+	as.Equal("感恩节", c.Festival())
+
+	UnregisterFestival("感恩节")
+	as.Equal("", c.Festival())
+}
+
+func TestRegisterFestivalReentrant(t *testing.T) {
+	as := assert.New(t)
+	// 节日函数中查询、注册节日不会死锁
+	RegisterFestival("春节次日", func(year int, tz *time.Location) *Carbon {
+		c, err := FestivalDate("春节", year, tz)
+		if err != nil {
+			return nil
+		}
+		RegisterFestival("元旦", SolarFestival(1, 1))
+		return CreateFromGo(c.time.AddDate(0, 0, 1))
+	})
+	defer UnregisterFestival("春节次日")
+	defer UnregisterFestival("元旦")
+
+	c, err := FestivalDate("春节次日", 2019)
+	as.NoError(err)
+	as.Equal("2019-02-06", c.ToDateString())
+	as.Equal([]string{"春节次日"}, c.Festivals())
+	as.Equal([]string{"元旦"}, Create(2019, 1, 1, 0, 0, 0, time.Local).Festivals())
+}
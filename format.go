@@ -0,0 +1,423 @@
+package carbon
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DateFormats FormatLocale、IsoFormat 使用的月份、星期、上下午名称及序数词
+type DateFormats struct {
+	// Months 月份名称，如 "January"
+	Months [12]string
+	// ShortMonths 月份简称，如 "Jan"
+	ShortMonths [12]string
+	// Weekdays 星期名称，从星期日开始，如 "Sunday"
+	Weekdays [7]string
+	// ShortWeekdays 星期简称，如 "Sun"
+	ShortWeekdays [7]string
+	// MinWeekdays 星期最短的简称，如 "Su"
+	MinWeekdays [7]string
+	// Meridiem 上午、下午，如 {"AM", "PM"}
+	Meridiem [2]string
+	// Ordinal 返回序数词，如 "1st"、"2."、"12日"
+	Ordinal func(n int) string
+	// FormattedDate ToFormattedDateString 使用的格式
+	FormattedDate string
+	// DayDateTime ToDayDateTimeString 使用的格式
+	DayDateTime string
+}
+
+var (
+	dateFormatsMu sync.RWMutex
+	dateFormats   = map[string]*DateFormats{}
+)
+
+// RegisterDateFormats 注册或替换 locale 语言的月份、星期等名称，优先于 RegisterLocale 注册的语言数据
+func RegisterDateFormats(locale string, formats *DateFormats) {
+	dateFormatsMu.Lock()
+	defer dateFormatsMu.Unlock()
+	dateFormats[locale] = formats
+}
+
+// dateFormatsOf 返回 locale 语言的名称，按回退链查找
+func dateFormatsOf(locale string) *DateFormats {
+	for _, name := range LocaleFallbacks(locale) {
+		dateFormatsMu.RLock()
+		f, ok := dateFormats[name]
+		dateFormatsMu.RUnlock()
+		if ok {
+			return f
+		}
+	}
+	return compiledLocaleOf(locale).date
+}
+
+// ordinal 返回 n 的序数词，未设置 Ordinal 时返回数值
+func (f *DateFormats) ordinal(n int) string {
+	if f.Ordinal == nil {
+		return strconv.Itoa(n)
+	}
+	return f.Ordinal(n)
+}
+
+// meridiem 返回 hour 对应的上午、下午名称
+func (f *DateFormats) meridiem(hour int) string {
+	if hour < 12 {
+		return f.Meridiem[0]
+	}
+	return f.Meridiem[1]
+}
+
+// layoutNames time.Format 布局中会输出英文名称的标记，较长的在前
+var layoutNames = []string{"January", "Jan", "Monday", "Mon", "PM", "pm"}
+
+// splitLayout 将 layout 拆分为名称标记及其余部分
+func splitLayout(layout string) []string {
+	var chunks []string
+	start := 0
+	for i := 0; i < len(layout); {
+		matched := ""
+		for _, name := range layoutNames {
+			if strings.HasPrefix(layout[i:], name) {
+				matched = name
+				break
+			}
+		}
+		if matched == "" {
+			i++
+			continue
+		}
+		if start < i {
+			chunks = append(chunks, layout[start:i])
+		}
+		chunks = append(chunks, matched)
+		i += len(matched)
+		start = i
+	}
+	if start < len(layout) {
+		chunks = append(chunks, layout[start:])
+	}
+	return chunks
+}
+
+// FormatLocale 同 Format，月份、星期及上下午使用 locale 语言的名称，
+// 如 c.FormatLocale("2006年1月2日 Monday PM", "zh-CN") 输出 "2019年4月12日 星期五 下午"
+func (c *Carbon) FormatLocale(layout, locale string) string {
+	f := dateFormatsOf(locale)
+	t := c.time
+	var b strings.Builder
+	for _, chunk := range splitLayout(layout) {
+		switch chunk {
+		case "January":
+			b.WriteString(f.Months[t.Month()-1])
+		case "Jan":
+			b.WriteString(f.ShortMonths[t.Month()-1])
+		case "Monday":
+			b.WriteString(f.Weekdays[t.Weekday()])
+		case "Mon":
+			b.WriteString(f.ShortWeekdays[t.Weekday()])
+		case "PM":
+			b.WriteString(f.meridiem(t.Hour()))
+		case "pm":
+			b.WriteString(strings.ToLower(f.meridiem(t.Hour())))
+		default:
+			b.WriteString(t.Format(chunk))
+		}
+	}
+	return b.String()
+}
+
+// localNames 返回名称标记对应的本地名称及英文名称，其他部分返回 nil
+func localNames(chunk string, f, en *DateFormats) ([]string, []string) {
+	switch chunk {
+	case "January":
+		return f.Months[:], en.Months[:]
+	case "Jan":
+		return f.ShortMonths[:], en.ShortMonths[:]
+	case "Monday":
+		return f.Weekdays[:], en.Weekdays[:]
+	case "Mon":
+		return f.ShortWeekdays[:], en.ShortWeekdays[:]
+	case "PM":
+		return f.Meridiem[:], []string{"AM", "PM"}
+	case "pm":
+		return []string{strings.ToLower(f.Meridiem[0]), strings.ToLower(f.Meridiem[1])}, []string{"am", "pm"}
+	}
+	return nil, nil
+}
+
+// ParseLocale 同 ParseFromLocale，value 中的月份、星期及上下午为 locale 语言的名称，
+// 名称只在 layout 中对应标记的位置匹配，如西班牙语的 "mar" 按位置区分星期二和三月
+func ParseLocale(layout, value, locale string, tz *time.Location) (*Carbon, error) {
+	f, en := dateFormatsOf(locale), dateFormatsOf("en")
+	var pattern strings.Builder
+	// 每个部分本地名称到英文名称的映射，非名称部分为 nil
+	var parts []map[string]string
+	pattern.WriteString("^")
+	for _, chunk := range splitLayout(layout) {
+		local, english := localNames(chunk, f, en)
+		if local == nil {
+			pattern.WriteString("(.*?)")
+			parts = append(parts, nil)
+			continue
+		}
+		names := make(map[string]string)
+		var alternatives []string
+		for i, name := range local {
+			if name != "" {
+				names[name] = english[i]
+				alternatives = append(alternatives, name)
+			}
+		}
+		// 较长的名称优先匹配，如 "11月" 优先于 "1月"
+		sort.SliceStable(alternatives, func(i, j int) bool { return len(alternatives[i]) > len(alternatives[j]) })
+		for i, name := range alternatives {
+			alternatives[i] = regexp.QuoteMeta(name)
+		}
+		pattern.WriteString("(" + strings.Join(alternatives, "|") + ")")
+		parts = append(parts, names)
+	}
+	pattern.WriteString("$")
+
+	match := regexp.MustCompile(pattern.String()).FindStringSubmatch(value)
+	if match == nil {
+		return &Carbon{}, ErrTimeParse
+	}
+	var b strings.Builder
+	for i, names := range parts {
+		if names == nil {
+			b.WriteString(match[i+1])
+		} else {
+			b.WriteString(names[match[i+1]])
+		}
+	}
+	t, err := time.ParseInLocation(layout, b.String(), tz)
+	if err != nil {
+		return &Carbon{}, ErrTimeParse
+	}
+	return CreateFromGo(t), nil
+}
+
+// isoTokens IsoFormat 支持的标记，较长的在前
+var isoTokens = []string{
+	"Yc", "YC", "Mc", "MC", "Dc", "DC",
+	"YYYY", "YY", "Qo", "Q",
+	"MMMM", "MMM", "MM", "Mo", "M",
+	"DDDD", "DDDo", "DDD", "DD", "Do", "D",
+	"dddd", "ddd", "dd", "do", "d", "E",
+	"WW", "Wo", "W",
+	"HH", "H", "hh", "h", "kk", "k", "mm", "m", "ss", "s",
+	"A", "a", "ZZ", "Z", "z", "X", "x",
+}
+
+// isoToken 返回 pattern 在 i 处的标记，S 可重复任意次表示秒的小数部分
+func isoToken(pattern string, i int) string {
+	if pattern[i] == 'S' {
+		j := i
+		for j < len(pattern) && pattern[j] == 'S' {
+			j++
+		}
+		return pattern[i:j]
+	}
+	for _, token := range isoTokens {
+		if strings.HasPrefix(pattern[i:], token) {
+			return token
+		}
+	}
+	return ""
+}
+
+// IsoFormat 按 ISO 风格的标记格式化时间，如 "YYYY-MM-DD HH:mm:ss"、"dddd, MMMM Do YYYY, h:mm A"，
+// 方括号中的内容原样输出。locale 为空时使用 "en"
+//
+// 支持的标记:
+//
+//	YYYY 2019  YY 19  Q 2  M 4  MM 04  MMM Apr  MMMM April  D 2  DD 02  Do 2nd  DDD 102  DDDD 102(补零)
+//	d 5(星期日为 0)  E 5(星期一为 1)  dd Fr  ddd Fri  dddd Friday  W WW ISO 周数
+//	H HH 24 小时  h hh 12 小时  k kk 1-24 小时  m mm 分  s ss 秒  S… 秒的小数部分
+//	A PM  a pm  Z +08:00  ZZ +0800  z CST  X 秒级时间戳  x 毫秒级时间戳  Mo Qo DDDo do Wo 序数词
+//	Yc 二〇一九  Mc 四  Dc 十二  YC 贰零壹玖  MC 零肆  DC 壹拾贰(中文数字及票据大写)
+func (c *Carbon) IsoFormat(pattern string, locale ...string) string {
+	f := dateFormatsOf("en")
+	if len(locale) > 0 {
+		f = dateFormatsOf(locale[0])
+	}
+	t := c.time
+	_, week := t.ISOWeek()
+	hour12 := t.Hour() % 12
+	if hour12 == 0 {
+		hour12 = 12
+	}
+	hour24 := t.Hour()
+	if hour24 == 0 {
+		hour24 = 24
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(pattern); {
+		if pattern[i] == '[' {
+			if end := strings.IndexByte(pattern[i:], ']'); end > 0 {
+				b.WriteString(pattern[i+1 : i+end])
+				i += end + 1
+				continue
+			}
+		}
+		token := isoToken(pattern, i)
+		if token == "" {
+			b.WriteByte(pattern[i])
+			i++
+			continue
+		}
+		i += len(token)
+
+		switch token {
+		case "Yc":
+			b.WriteString(chineseYear(t.Year(), false))
+		case "YC":
+			b.WriteString(chineseYear(t.Year(), true))
+		case "Mc":
+			b.WriteString(chineseNumber(int(t.Month())))
+		case "MC":
+			b.WriteString(chineseUpperNumber(int(t.Month())))
+		case "Dc":
+			b.WriteString(chineseNumber(t.Day()))
+		case "DC":
+			b.WriteString(chineseUpperNumber(t.Day()))
+		case "YYYY":
+			b.WriteString(pad(t.Year(), 4))
+		case "YY":
+			b.WriteString(pad(t.Year()%100, 2))
+		case "Q":
+			b.WriteString(strconv.Itoa((int(t.Month())-1)/3 + 1))
+		case "Qo":
+			b.WriteString(f.ordinal((int(t.Month())-1)/3 + 1))
+		case "M":
+			b.WriteString(strconv.Itoa(int(t.Month())))
+		case "MM":
+			b.WriteString(pad(int(t.Month()), 2))
+		case "Mo":
+			b.WriteString(f.ordinal(int(t.Month())))
+		case "MMM":
+			b.WriteString(f.ShortMonths[t.Month()-1])
+		case "MMMM":
+			b.WriteString(f.Months[t.Month()-1])
+		case "D":
+			b.WriteString(strconv.Itoa(t.Day()))
+		case "DD":
+			b.WriteString(pad(t.Day(), 2))
+		case "Do":
+			b.WriteString(f.ordinal(t.Day()))
+		case "DDD":
+			b.WriteString(strconv.Itoa(t.YearDay()))
+		case "DDDD":
+			b.WriteString(pad(t.YearDay(), 3))
+		case "DDDo":
+			b.WriteString(f.ordinal(t.YearDay()))
+		case "d":
+			b.WriteString(strconv.Itoa(int(t.Weekday())))
+		case "do":
+			b.WriteString(f.ordinal(int(t.Weekday())))
+		case "E":
+			b.WriteString(strconv.Itoa((int(t.Weekday())+6)%7 + 1))
+		case "dd":
+			b.WriteString(f.MinWeekdays[t.Weekday()])
+		case "ddd":
+			b.WriteString(f.ShortWeekdays[t.Weekday()])
+		case "dddd":
+			b.WriteString(f.Weekdays[t.Weekday()])
+		case "W":
+			b.WriteString(strconv.Itoa(week))
+		case "WW":
+			b.WriteString(pad(week, 2))
+		case "Wo":
+			b.WriteString(f.ordinal(week))
+		case "H":
+			b.WriteString(strconv.Itoa(t.Hour()))
+		case "HH":
+			b.WriteString(pad(t.Hour(), 2))
+		case "h":
+			b.WriteString(strconv.Itoa(hour12))
+		case "hh":
+			b.WriteString(pad(hour12, 2))
+		case "k":
+			b.WriteString(strconv.Itoa(hour24))
+		case "kk":
+			b.WriteString(pad(hour24, 2))
+		case "m":
+			b.WriteString(strconv.Itoa(t.Minute()))
+		case "mm":
+			b.WriteString(pad(t.Minute(), 2))
+		case "s":
+			b.WriteString(strconv.Itoa(t.Second()))
+		case "ss":
+			b.WriteString(pad(t.Second(), 2))
+		case "A":
+			b.WriteString(f.meridiem(t.Hour()))
+		case "a":
+			b.WriteString(strings.ToLower(f.meridiem(t.Hour())))
+		case "Z":
+			b.WriteString(t.Format("-07:00"))
+		case "ZZ":
+			b.WriteString(t.Format("-0700"))
+		case "z":
+			b.WriteString(t.Format("MST"))
+		case "X":
+			b.WriteString(strconv.FormatInt(t.Unix(), 10))
+		case "x":
+			// 与 TimestampMilli 相同，避免 UnixNano 在 1678 年前、2262 年后溢出
+			b.WriteString(strconv.FormatInt(t.Unix()*1e3+int64(t.Nanosecond())/1e6, 10))
+		default:
+			// 秒的小数部分，最多 9 位
+			digits := pad(t.Nanosecond(), 9) + strings.Repeat("0", len(token))
+			b.WriteString(digits[:len(token)])
+		}
+	}
+	return b.String()
+}
+
+// isoLayouts IsoFormat 标记对应的 time.Format 布局，不在其中的标记无法解析
+var isoLayouts = map[string]string{
+	"YYYY": "2006", "YY": "06",
+	"M": "1", "MM": "01", "MMM": "Jan", "MMMM": "January",
+	"D": "2", "DD": "02", "DDDD": "002",
+	"ddd": "Mon", "dddd": "Monday",
+	"H": "15", "HH": "15", "h": "3", "hh": "03",
+	"m": "4", "mm": "04", "s": "5", "ss": "05",
+	"A": "PM", "a": "pm", "Z": "-07:00", "ZZ": "-0700", "z": "MST",
+}
+
+// ParseIsoFormat 按 IsoFormat 的标记解析 locale 语言的时间字符串，
+// 秒的小数部分 S… 需紧跟在 "." 之后，序数词、季度、时间戳等标记不支持解析，中文数字的日期使用 ParseChineseDate 解析
+func ParseIsoFormat(pattern, value, locale string, tz *time.Location) (*Carbon, error) {
+	var layout strings.Builder
+	for i := 0; i < len(pattern); {
+		if pattern[i] == '[' {
+			if end := strings.IndexByte(pattern[i:], ']'); end > 0 {
+				layout.WriteString(pattern[i+1 : i+end])
+				i += end + 1
+				continue
+			}
+		}
+		token := isoToken(pattern, i)
+		if token == "" {
+			layout.WriteByte(pattern[i])
+			i++
+			continue
+		}
+		i += len(token)
+		if token[0] == 'S' {
+			layout.WriteString(strings.Repeat("0", len(token)))
+			continue
+		}
+		l, ok := isoLayouts[token]
+		if !ok {
+			return &Carbon{}, ErrTimeParse
+		}
+		layout.WriteString(l)
+	}
+	return ParseLocale(layout.String(), value, locale, tz)
+}
@@ -0,0 +1,127 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarbon_FormatLocale(t *testing.T) {
+	as := assert.New(t)
+	c := Create(2019, 4, 12, 15, 4, 5, time.UTC)
+	as.Equal("2019年4月12日 星期五 下午", c.FormatLocale("2006年1月2日 Monday PM", "zh-CN"))
+	as.Equal("2019年4月12日 金曜日 午後3:04", c.FormatLocale("2006年1月2日 Monday PM3:04", "ja"))
+	as.Equal("Freitag, 12. April 2019", c.FormatLocale("Monday, 2. January 2006", "de"))
+	as.Equal("ven. 12 avr. 2019", c.FormatLocale("Mon 2 Jan 2006", "fr"))
+	as.Equal("viernes 12 abril 2019 3 p. m.", c.FormatLocale("Monday 2 January 2006 3 PM", "es"))
+	as.Equal("Friday, April 12 2019 3pm", c.FormatLocale("Monday, January 2 2006 3pm", "en"))
+	as.Equal("Friday 12 Apr", c.FormatLocale("Monday 2 Jan", "xx"))
+	as.Equal("上午", Create(2019, 4, 12, 11, 59, 59, time.UTC).FormatLocale("PM", "zh-CN"))
+
+	as.Equal("Apr 12,2019", c.ToFormattedDateString())
+	as.Equal("2019年4月12日", c.ToFormattedDateString("zh-CN"))
+	as.Equal("12. Apr 2019", c.ToFormattedDateString("de"))
+	as.Equal("Fri, Apr 12, 2019 3:04 PM", c.ToDayDateTimeString())
+	as.Equal("2019年4月12日 星期五 下午3:04", c.ToDayDateTimeString("zh-CN"))
+	as.Equal("2019년 4월 12일 금요일 오후 3:04", c.ToDayDateTimeString("ko"))
+	as.Equal("Fr, 12. Apr 2019 15:04", c.ToDayDateTimeString("de"))
+}
+
+func TestParseLocale(t *testing.T) {
+	as := assert.New(t)
+	want := "2019-04-12 15:04:00"
+	tests := []struct {
+		layout, value, locale string
+	}{
+		{"2006年1月2日 Monday PM3:04", "2019年4月12日 星期五 下午3:04", "zh-CN"},
+		{"2006年1月2日 Monday PM3:04", "2019年4月12日 金曜日 午後3:04", "ja"},
+		{"Monday, 2. January 2006 15:04", "Freitag, 12. April 2019 15:04", "de"},
+		{"Mon 2 Jan 2006 15:04", "ven. 12 avr. 2019 15:04", "fr"},
+		{"2 January 2006 3:04 PM", "12 abril 2019 3:04 p. m.", "es"},
+		{"Jan 2 2006 3:04pm", "Apr 12 2019 3:04pm", "en"},
+	}
+	for _, tt := range tests {
+		c, err := ParseLocale(tt.layout, tt.value, tt.locale, time.UTC)
+		as.Nil(err, tt.value)
+		as.Equal(want, c.ToDateTimeString(), tt.value)
+	}
+
+	// 11月 不会被当作 1月
+	c, err := ParseLocale("2006 Jan 2", "2019 11月 12", "zh-CN", time.UTC)
+	as.Nil(err)
+	as.Equal("2019-11-12", c.ToDateString())
+
+	// 每个语言格式化后都可以解析回来，3 月包含西班牙语星期二与三月同名的情况
+	for _, ref := range []*Carbon{Create(2019, 11, 3, 9, 30, 0, time.UTC), Create(2019, 3, 15, 15, 4, 0, time.UTC), Create(2019, 3, 19, 15, 4, 0, time.UTC)} {
+		for _, locale := range []string{"en", "zh-CN", "zh-TW", "ja", "ko", "de", "fr", "es"} {
+			f := dateFormatsOf(locale)
+			for _, layout := range []string{f.FormattedDate, f.DayDateTime, "Monday January 2 2006 3:04 PM", "Mon Jan 2 2006"} {
+				s := ref.FormatLocale(layout, locale)
+				c, err := ParseLocale(layout, s, locale, time.UTC)
+				as.Nil(err, s)
+				as.Equal(ref.ToDateString(), c.ToDateString(), s)
+			}
+		}
+	}
+	march := Create(2019, 3, 15, 15, 4, 0, time.UTC)
+	s := march.ToDayDateTimeString("es")
+	c, err = ParseLocale(dateFormatsOf("es").DayDateTime, s, "es", time.UTC)
+	as.Nil(err, s)
+	as.Equal("2019-03-15 15:04:00", c.ToDateTimeString(), s)
+	// 星期与月份同名时按位置匹配
+	c, err = ParseLocale("Mon 2 Jan 2006", "mar 5 mar 2019", "es", time.UTC)
+	as.Nil(err)
+	as.Equal("2019-03-05", c.ToDateString())
+
+	_, err = ParseLocale("2006 January", "2019 Foo", "de", time.UTC)
+	as.Equal(ErrTimeParse, err)
+}
+
+func TestCarbon_IsoFormat(t *testing.T) {
+	as := assert.New(t)
+	c := CreateFromGo(time.Date(2019, 4, 2, 0, 4, 5, 123456789, time.FixedZone("CST", 8*3600)))
+	as.Equal("2019-04-02 00:04:05", c.IsoFormat("YYYY-MM-DD HH:mm:ss"))
+	as.Equal("Tuesday, April 2nd 2019, 12:04 AM", c.IsoFormat("dddd, MMMM Do YYYY, h:mm A"))
+	as.Equal("19 4 2 0 4 5 Tu Tue", c.IsoFormat("YY M D H m s dd ddd"))
+	as.Equal("Q2 2nd 92 092 92nd", c.IsoFormat("[Q]Q Qo DDD DDDD DDDo"))
+	as.Equal("2 2 14 14 14th 24 12 am", c.IsoFormat("d E W WW Wo kk hh a"))
+	as.Equal("1 12 123 123456789", c.IsoFormat("S SS SSS SSSSSSSSS"))
+	as.Equal("+08:00 +0800 CST", c.IsoFormat("Z ZZ z"))
+	as.Equal("1554134645 1554134645123", c.IsoFormat("X x"))
+	as.Equal("-11676096000 -11676096000000", Create(1600, 1, 1, 0, 0, 0, time.UTC).IsoFormat("X x"))
+	as.Equal("10413792000 10413792000000", Create(2300, 1, 1, 0, 0, 0, time.UTC).IsoFormat("X x"))
+	as.Equal("[YYYY] 2019", c.IsoFormat("[[YYYY]] YYYY"))
+	as.Equal("2019年4月2日 星期二 上午", c.IsoFormat("YYYY年M月D日 dddd A", "zh-CN"))
+	as.Equal("2. April 2019", c.IsoFormat("Do MMMM YYYY", "de"))
+	as.Equal("1er avril", Create(2019, 4, 1, 0, 0, 0, time.UTC).IsoFormat("Do MMMM", "fr"))
+	for n, want := range map[int]string{11: "11th", 12: "12th", 13: "13th", 21: "21st", 22: "22nd", 23: "23rd", 101: "101st", 111: "111th"} {
+		as.Equal(want, dateFormatsOf("en").Ordinal(n))
+	}
+}
+
+func TestParseIsoFormat(t *testing.T) {
+	as := assert.New(t)
+	c, err := ParseIsoFormat("YYYY-MM-DD HH:mm:ss.SSS", "2019-04-02 09:04:05.123", "en", time.UTC)
+	as.Nil(err)
+	as.Equal("2019-04-02 09:04:05.123", c.Format("2006-01-02 15:04:05.000"))
+
+	c, err = ParseIsoFormat("YYYY年M月D日 dddd A h:mm", "2019年4月2日 星期二 下午 3:04", "zh-CN", time.UTC)
+	as.Nil(err)
+	as.Equal("2019-04-02 15:04:00", c.ToDateTimeString())
+
+	// 星期简称 "日"、"月" 不影响日期中的 "日"、"月"
+	c, err = ParseIsoFormat("YYYY年M月D日 ddd", "2019年4月12日 金", "ja", time.UTC)
+	as.Nil(err)
+	as.Equal("2019-04-12", c.ToDateString())
+	c, err = ParseIsoFormat("YYYY年M月D日 ddd", "2019年4月14日 日", "ja", time.UTC)
+	as.Nil(err)
+	as.Equal("2019-04-14", c.ToDateString())
+
+	c, err = ParseIsoFormat("D MMMM YYYY H:mm", "2 avril 2019 9:04", "fr", time.UTC)
+	as.Nil(err)
+	as.Equal("2019-04-02 09:04:00", c.ToDateTimeString())
+
+	_, err = ParseIsoFormat("Do MMMM YYYY", "2nd April 2019", "en", time.UTC)
+	as.Equal(ErrTimeParse, err)
+}
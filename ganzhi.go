@@ -0,0 +1,91 @@
+package carbon
+
+import "time"
+
+var (
+	heavenlyStems   = []string{"甲", "乙", "丙", "丁", "戊", "己", "庚", "辛", "壬", "癸"}
+	earthlyBranches = []string{"子", "丑", "寅", "卯", "辰", "巳", "午", "未", "申", "酉", "戌", "亥"}
+	animals         = []string{"鼠", "牛", "虎", "兔", "龙", "蛇", "马", "羊", "猴", "鸡", "狗", "猪"}
+)
+
+// YearBoundary 干支纪年和生肖的换年方式
+type YearBoundary int
+
+const (
+	// LunarNewYear 以农历正月初一换年
+	LunarNewYear YearBoundary = iota
+	// StartOfSpring 以立春交节时刻换年
+	StartOfSpring
+)
+
+// ganZhi 返回六十甲子中第 n 个(0 为甲子)的干支
+func ganZhi(n int) string {
+	n = (n%60 + 60) % 60
+	return heavenlyStems[n%10] + earthlyBranches[n%12]
+}
+
+// springYear 返回以立春换年的年份
+func (c *Carbon) springYear() int {
+	year := c.time.Year()
+	if c.time.Before(solarTermOf(year, 2, c.time.Location()).Time.time) {
+		year--
+	}
+	return year
+}
+
+// ganZhiYearOf 按换年方式返回年份，默认以农历正月初一换年
+func (c *Carbon) ganZhiYearOf(boundary []YearBoundary) int {
+	if len(boundary) == 0 || boundary[0] == LunarNewYear {
+		if l := c.Lunar(); l != nil {
+			return l.Year
+		}
+	}
+	return c.springYear()
+}
+
+// GanZhiYear 返回干支纪年，如 "己亥"，默认以农历正月初一换年
+func (c *Carbon) GanZhiYear(boundary ...YearBoundary) string {
+	return ganZhi(c.ganZhiYearOf(boundary) - 4)
+}
+
+// GanZhiMonth 返回干支纪月，如 "戊辰"，以节气(立春、惊蛰等)交节时刻换月
+func (c *Carbon) GanZhiMonth() string {
+	term := c.SolarTerm()
+	if c.time.Before(term.Time.time) {
+		// 当天交节但还未到交节时刻
+		term = solarTermOf(term.Time.time.Year(), term.Index-1, c.time.Location())
+	}
+	// 以寅月(立春)为 0 计数，小寒所在的丑月为 11
+	month := (term.Index/2 + 11) % 12
+	year := c.springYear()
+	stem := ((year-4)%10*2 + 2 + month) % 10
+	return heavenlyStems[(stem+10)%10] + earthlyBranches[(month+2)%12]
+}
+
+// GanZhiDay 返回干支纪日，如 "戊午"，以零时换日
+func (c *Carbon) GanZhiDay() string {
+	return ganZhi(c.julianDayNumber() + 49)
+}
+
+// GanZhiHour 返回干支纪时，如 "甲子"。23 时起为子时，天干按次日推算
+func (c *Carbon) GanZhiHour() string {
+	branch := (c.time.Hour() + 1) / 2 % 12
+	day := c.julianDayNumber() + 49
+	if c.time.Hour() == 23 {
+		day++
+	}
+	stem := ((day%10)*2 + branch) % 10
+	return heavenlyStems[stem] + earthlyBranches[branch]
+}
+
+// Animal 返回生肖，如 "猪"，默认以农历正月初一换年
+func (c *Carbon) Animal(boundary ...YearBoundary) string {
+	return animals[((c.ganZhiYearOf(boundary)-4)%12+12)%12]
+}
+
+// julianDayNumber 返回当天的儒略日数
+func (c *Carbon) julianDayNumber() int {
+	y, m, d := c.time.Date()
+	date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return int(jdUnixEpoch+0.5) + int(date.Unix()/86400)
+}
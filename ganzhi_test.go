@@ -0,0 +1,54 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarbon_GanZhi(t *testing.T) {
+	as := assert.New(t)
+	shanghai := time.FixedZone("CST", 8*3600)
+	tests := []struct {
+		date                   *Carbon
+		year, month, day, hour string
+	}{
+		{Create(1949, 10, 1, 15, 0, 0, shanghai), "己丑", "癸酉", "甲子", "壬申"},
+		{Create(2000, 1, 1, 0, 30, 0, shanghai), "己卯", "丙子", "戊午", "壬子"},
+		{Create(2019, 4, 12, 10, 0, 0, shanghai), "己亥", "戊辰", "己卯", "己巳"},
+		{Create(2019, 4, 12, 23, 30, 0, shanghai), "己亥", "戊辰", "己卯", "丙子"},
+		{Create(2020, 1, 25, 12, 0, 0, shanghai), "庚子", "丁丑", "丁卯", "丙午"},
+	}
+	for _, tt := range tests {
+		as.Equal(tt.year, tt.date.GanZhiYear(), tt.date.String())
+		as.Equal(tt.month, tt.date.GanZhiMonth(), tt.date.String())
+		as.Equal(tt.day, tt.date.GanZhiDay(), tt.date.String())
+		as.Equal(tt.hour, tt.date.GanZhiHour(), tt.date.String())
+	}
+}
+
+func TestCarbon_GanZhiMonth(t *testing.T) {
+	as := assert.New(t)
+	shanghai := time.FixedZone("CST", 8*3600)
+	// 2019 年立春交节时刻为 11:14
+	as.Equal("乙丑", Create(2019, 2, 4, 11, 0, 0, shanghai).GanZhiMonth())
+	as.Equal("丙寅", Create(2019, 2, 4, 11, 30, 0, shanghai).GanZhiMonth())
+}
+
+func TestCarbon_Animal(t *testing.T) {
+	as := assert.New(t)
+	shanghai := time.FixedZone("CST", 8*3600)
+
+	// 2019 年立春在正月初一之前
+	c := Create(2019, 2, 4, 12, 0, 0, shanghai)
+	as.Equal("狗", c.Animal())
+	as.Equal("猪", c.Animal(StartOfSpring))
+	as.Equal("戊戌", c.GanZhiYear())
+	as.Equal("己亥", c.GanZhiYear(StartOfSpring))
+
+	// 2020 年正月初一在立春之前
+	c = Create(2020, 1, 30, 12, 0, 0, shanghai)
+	as.Equal("鼠", c.Animal(LunarNewYear))
+	as.Equal("猪", c.Animal(StartOfSpring))
+}
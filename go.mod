@@ -2,4 +2,7 @@ module github.com/kingzcheung/carbon
 
 go 1.12
 
-require github.com/stretchr/testify v1.3.0
+require (
+	github.com/stretchr/testify v1.3.0
+	gopkg.in/yaml.v2 v2.4.0
+)
@@ -0,0 +1,79 @@
+package carbon
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// httpDateLayouts RFC 9110 允许的 HTTP 日期格式：IMF-fixdate、RFC 850、asctime
+var httpDateLayouts = []string{
+	Rfc7231Layout,
+	"Monday, 02-Jan-06 15:04:05 GMT",
+	"Mon Jan _2 15:04:05 2006",
+}
+
+// ParseHTTPDate 解析 HTTP 日期，支持 "Fri, 12 Apr 2019 07:04:05 GMT"、"Friday, 12-Apr-19 07:04:05 GMT"、
+// "Fri Apr 12 07:04:05 2019" 三种格式，与 net/http 一致不校验星期，返回 tz 时区的时间
+func ParseHTTPDate(value string, tz *time.Location) (*Carbon, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range httpDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return CreateFromGo(t.In(tz)), nil
+		}
+	}
+	return &Carbon{}, ErrTimeParse
+}
+
+// ToHTTPDateString 返回 HTTP 日期，如 "Fri, 12 Apr 2019 07:04:05 GMT"，
+// 用于 Last-Modified、Expires 等响应头，同 ToRfc7231String
+func (c *Carbon) ToHTTPDateString() string {
+	return c.ToRfc7231String()
+}
+
+// RetryAfter 返回 Retry-After 响应头的值，seconds 为 true 时返回距现在的秒数(向上取整，已过去时为 "0")，
+// 否则返回 HTTP 日期
+func (c *Carbon) RetryAfter(seconds bool) string {
+	if !seconds {
+		return c.ToHTTPDateString()
+	}
+	delay := math.Ceil(c.time.Sub(time.Now()).Seconds())
+	if delay < 0 {
+		delay = 0
+	}
+	return strconv.FormatInt(int64(delay), 10)
+}
+
+// ParseRetryAfter 解析 Retry-After 请求头，值可以是秒数或 HTTP 日期，返回可以重试的时间。
+// 秒数相对于 reference，reference 为 nil 时相对于现在
+func ParseRetryAfter(value string, reference *Carbon) (*Carbon, error) {
+	if reference == nil {
+		reference = Now()
+	}
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return CreateFromGo(reference.time.Add(time.Duration(seconds) * time.Second)), nil
+	}
+	return ParseHTTPDate(value, reference.time.Location())
+}
+
+// IsModifiedSince 判断以当前时间为 Last-Modified 的资源在 If-Modified-Since 请求头 header 之后是否修改过，
+// HTTP 日期只精确到秒，header 为空或无法解析时视为已修改
+func (c *Carbon) IsModifiedSince(header string) bool {
+	since, err := ParseHTTPDate(header, time.UTC)
+	if err != nil {
+		return true
+	}
+	return c.time.Truncate(time.Second).After(since.time)
+}
+
+// IsUnmodifiedSince 判断以当前时间为 Last-Modified 的资源在 If-Unmodified-Since 请求头 header 之后是否未修改，
+// header 为空或无法解析时忽略该条件，返回 true
+func (c *Carbon) IsUnmodifiedSince(header string) bool {
+	since, err := ParseHTTPDate(header, time.UTC)
+	if err != nil {
+		return true
+	}
+	return !c.time.Truncate(time.Second).After(since.time)
+}
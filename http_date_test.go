@@ -0,0 +1,96 @@
+package carbon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHTTPDate(t *testing.T) {
+	as := assert.New(t)
+	tz := time.FixedZone("CST", 8*3600)
+	want := time.Date(2019, 4, 12, 7, 4, 5, 0, time.UTC)
+	for _, value := range []string{
+		"Fri, 12 Apr 2019 07:04:05 GMT",
+		"Friday, 12-Apr-19 07:04:05 GMT",
+		"Fri Apr 12 07:04:05 2019",
+		" Fri, 12 Apr 2019 07:04:05 GMT ",
+	} {
+		c, err := ParseHTTPDate(value, tz)
+		as.Nil(err, value)
+		as.True(c.time.Equal(want), value)
+		as.Equal("2019-04-12 15:04:05", c.ToDateTimeString(), value)
+	}
+
+	c, err := ParseHTTPDate("Sun Nov  6 08:49:37 1994", time.UTC)
+	as.Nil(err)
+	as.Equal("Sun, 06 Nov 1994 08:49:37 GMT", c.ToHTTPDateString())
+
+	for _, value := range []string{"", "2019-04-12 07:04:05", "Fri, 12 Apr 2019 07:04:05 +0000"} {
+		_, err := ParseHTTPDate(value, time.UTC)
+		as.Equal(ErrTimeParse, err, value)
+	}
+}
+
+func TestCarbon_RetryAfter(t *testing.T) {
+	as := assert.New(t)
+	as.Equal("120", Now().AddSeconds(120).RetryAfter(true))
+	as.Equal("0", Now().SubSeconds(10).RetryAfter(true))
+
+	c := CreateFromGo(time.Date(2019, 4, 12, 15, 4, 5, 0, time.FixedZone("CST", 8*3600)))
+	as.Equal("Fri, 12 Apr 2019 07:04:05 GMT", c.RetryAfter(false))
+
+	retry, err := ParseRetryAfter("120", c)
+	as.Nil(err)
+	as.Equal("2019-04-12 15:06:05", retry.ToDateTimeString())
+	retry, err = ParseRetryAfter("Fri, 12 Apr 2019 07:14:05 GMT", c)
+	as.Nil(err)
+	as.Equal("2019-04-12 15:14:05", retry.ToDateTimeString())
+	_, err = ParseRetryAfter("-1", c)
+	as.Equal(ErrTimeParse, err)
+}
+
+func TestCarbon_IsModifiedSince(t *testing.T) {
+	as := assert.New(t)
+	modified := CreateFromGo(time.Date(2019, 4, 12, 7, 4, 5, 500, time.UTC))
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !modified.IsUnmodifiedSince(r.Header.Get("If-Unmodified-Since")) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		w.Header().Set("Last-Modified", modified.ToHTTPDateString())
+		if !modified.IsModifiedSince(r.Header.Get("If-Modified-Since")) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	tests := []struct {
+		header, value string
+		status        int
+	}{
+		{"", "", http.StatusOK},
+		{"If-Modified-Since", "Fri, 12 Apr 2019 07:04:05 GMT", http.StatusNotModified},
+		{"If-Modified-Since", "Friday, 12-Apr-19 08:00:00 GMT", http.StatusNotModified},
+		{"If-Modified-Since", "Fri Apr 12 07:04:04 2019", http.StatusOK},
+		{"If-Modified-Since", "invalid", http.StatusOK},
+		{"If-Unmodified-Since", "Fri, 12 Apr 2019 07:04:05 GMT", http.StatusOK},
+		{"If-Unmodified-Since", "Fri, 12 Apr 2019 07:04:04 GMT", http.StatusPreconditionFailed},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if test.header != "" {
+			req.Header.Set(test.header, test.value)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		as.Equal(test.status, rec.Code, test.value)
+		if test.status != http.StatusPreconditionFailed {
+			as.Equal("Fri, 12 Apr 2019 07:04:05 GMT", rec.Header().Get("Last-Modified"))
+		}
+	}
+}
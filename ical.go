@@ -0,0 +1,688 @@
+package carbon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// icalProdID 导出 iCalendar 时的 PRODID
+	icalProdID = "-//kingzcheung//carbon//EN"
+	// icalLineLength iCalendar 每行的最大字节数，超过时折行
+	icalLineLength = 75
+	// icalZoneUntil VTIMEZONE 规则展开到的年份
+	icalZoneUntil = 2100
+)
+
+var (
+	icalDurationRegexp = regexp.MustCompile(`^([+-])?P(?:(\d+)W|(\d+D)?(?:T(\d+H)?(\d+M)?(\d+S)?)?)$`)
+	icalTextEscaper    = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	icalTextUnescaper  = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+)
+
+// ParseICalDateTime 解析 iCalendar 的 DATE 或 DATE-TIME，如 "20190412"、"20190412T090000Z"，
+// 不带 Z 的浮动时间按 tz 解析
+func ParseICalDateTime(value string, tz *time.Location) (*Carbon, error) {
+	t, _, err := parseICalTime(value, nil, tz)
+	if err != nil {
+		return &Carbon{}, err
+	}
+	return CreateFromGo(t), nil
+}
+
+// ToICalDateString 输出 iCalendar 的 DATE，如 "20190412"
+func (c *Carbon) ToICalDateString() string {
+	return c.time.Format("20060102")
+}
+
+// ToICalDateTimeString 输出 iCalendar 不带时区的 DATE-TIME，如 "20190412T090000"，用于浮动时间或 TZID 参数
+func (c *Carbon) ToICalDateTimeString() string {
+	return c.time.Format("20060102T150405")
+}
+
+// ToICalUTCString 输出 iCalendar 的 UTC DATE-TIME，如 "20190412T010000Z"
+func (c *Carbon) ToICalUTCString() string {
+	return c.time.UTC().Format("20060102T150405Z")
+}
+
+// ICalDuration iCalendar 的 DURATION，天、周按日历计算，跨越夏令时仍为同一时刻
+type ICalDuration struct {
+	Negative                             bool
+	Weeks, Days, Hours, Minutes, Seconds int
+}
+
+// NewICalDuration 由 time.Duration 生成 DURATION，整天的部分作为天，不足 1 秒的部分舍去
+func NewICalDuration(d time.Duration) *ICalDuration {
+	id := &ICalDuration{Negative: d < 0}
+	if d < 0 {
+		d = -d
+	}
+	seconds := int(d / time.Second)
+	id.Days, seconds = seconds/86400, seconds%86400
+	id.Hours, id.Minutes, id.Seconds = seconds/3600, seconds%3600/60, seconds%60
+	if id.Days > 0 && id.Days%7 == 0 && seconds == 0 {
+		id.Weeks, id.Days = id.Days/7, 0
+	}
+	return id
+}
+
+// ParseICalDuration 解析 iCalendar 的 DURATION，如 "P1W"、"-PT15M"、"P1DT2H"
+func ParseICalDuration(value string) (*ICalDuration, error) {
+	m := icalDurationRegexp.FindStringSubmatch(value)
+	if m == nil || strings.HasSuffix(value, "P") || strings.HasSuffix(value, "T") {
+		return nil, ErrDurationParse
+	}
+	number := func(s string) int {
+		n, _ := strconv.Atoi(strings.TrimRight(s, "WDHMS"))
+		return n
+	}
+	return &ICalDuration{
+		Negative: m[1] == "-",
+		Weeks:    number(m[2]),
+		Days:     number(m[3]),
+		Hours:    number(m[4]),
+		Minutes:  number(m[5]),
+		Seconds:  number(m[6]),
+	}, nil
+}
+
+// String 输出 iCalendar 的 DURATION，如 "P1DT2H"，为 0 时输出 "PT0S"
+func (d *ICalDuration) String() string {
+	s := "P"
+	if d.Negative {
+		s = "-P"
+	}
+	if d.Weeks > 0 && d.Days == 0 && d.Hours == 0 && d.Minutes == 0 && d.Seconds == 0 {
+		return s + strconv.Itoa(d.Weeks) + "W"
+	}
+	if days := d.Weeks*7 + d.Days; days > 0 {
+		s += strconv.Itoa(days) + "D"
+	}
+	if d.Hours == 0 && d.Minutes == 0 && d.Seconds == 0 {
+		if s == "P" || s == "-P" {
+			return "PT0S"
+		}
+		return s
+	}
+	// 按 RFC 5545 秒只能跟在分之后，有时、秒时即使分为 0 也要输出
+	s += "T"
+	if d.Hours > 0 {
+		s += strconv.Itoa(d.Hours) + "H"
+	}
+	if d.Minutes > 0 || d.Hours > 0 && d.Seconds > 0 {
+		s += strconv.Itoa(d.Minutes) + "M"
+	}
+	if d.Seconds > 0 {
+		s += strconv.Itoa(d.Seconds) + "S"
+	}
+	return s
+}
+
+// Duration 返回对应的 time.Duration，每天按 24 小时计算
+func (d *ICalDuration) Duration() time.Duration {
+	total := time.Duration((d.Weeks*7+d.Days)*86400+d.Hours*3600+d.Minutes*60+d.Seconds) * time.Second
+	if d.Negative {
+		return -total
+	}
+	return total
+}
+
+// AddICalDuration 当前结构体加上 iCalendar 的 DURATION，天、周按日历相加，其余按实际时长相加
+func (c *Carbon) AddICalDuration(d *ICalDuration) *Carbon {
+	sign := 1
+	if d.Negative {
+		sign = -1
+	}
+	*c = *CreateFromGo(c.time.AddDate(0, 0, sign*(d.Weeks*7+d.Days)).
+		Add(time.Duration(sign*(d.Hours*3600+d.Minutes*60+d.Seconds)) * time.Second))
+	return c
+}
+
+// ICalEvent iCalendar 的 VEVENT
+type ICalEvent struct {
+	UID, Summary, Description, Location string
+	// Stamp DTSTAMP，导出时为 nil 则使用现在
+	Stamp *Carbon
+	// Start DTSTART，AllDay 时为当天 00:00
+	Start *Carbon
+	// End DTEND，只有 DURATION 时由 Start 加 Duration 计算
+	End *Carbon
+	// Duration DURATION，导出时不为 nil 则代替 DTEND
+	Duration *ICalDuration
+	// AllDay DTSTART 是否是日期
+	AllDay  bool
+	RRule   *RRule
+	ExDates []*Carbon
+}
+
+// RRuleSet 返回事件的重复时间集合，没有 RRULE 时只包含 Start
+func (e *ICalEvent) RRuleSet() *RRuleSet {
+	set := &RRuleSet{Dtstart: e.Start, ExDates: e.ExDates}
+	if e.RRule != nil {
+		set.RRules = []*RRule{e.RRule}
+	} else {
+		set.RDates = []*Carbon{e.Start}
+	}
+	return set
+}
+
+// icalProperty iCalendar 的属性
+type icalProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// icalComponent iCalendar 的组件，如 VEVENT、VTIMEZONE
+type icalComponent struct {
+	name       string
+	properties []icalProperty
+	children   []*icalComponent
+}
+
+// get 返回第一个名为 name 的属性
+func (c *icalComponent) get(name string) (icalProperty, bool) {
+	for _, p := range c.properties {
+		if p.name == name {
+			return p, true
+		}
+	}
+	return icalProperty{}, false
+}
+
+// parseICalComponents 解析 iCalendar 文本为组件，折行的内容会合并
+func parseICalComponents(data string) ([]*icalComponent, error) {
+	data = strings.Replace(data, "\r\n", "\n", -1)
+	data = strings.NewReplacer("\n ", "", "\n\t", "").Replace(data)
+	var roots, stack []*icalComponent
+	for _, line := range strings.Split(data, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		name, params, value := splitICalLine(line)
+		switch name {
+		case "":
+			return nil, ErrICalParse
+		case "BEGIN":
+			c := &icalComponent{name: strings.ToUpper(value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, c)
+			} else {
+				roots = append(roots, c)
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].name != strings.ToUpper(value) {
+				return nil, ErrICalParse
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, ErrICalParse
+			}
+			c := stack[len(stack)-1]
+			c.properties = append(c.properties, icalProperty{name, params, value})
+		}
+	}
+	if len(stack) > 0 {
+		return nil, ErrICalParse
+	}
+	return roots, nil
+}
+
+// icalReader 读取 VEVENT 时使用的时区，TZID 不是 IANA 名称时使用 VTIMEZONE 生成的时区
+type icalReader struct {
+	zones map[string]*time.Location
+}
+
+// location 返回 TZID 对应的时区，没有 TZID 时返回本地时区
+func (r *icalReader) location(params map[string]string) (*time.Location, error) {
+	tzid := params["TZID"]
+	if tzid == "" {
+		return time.Local, nil
+	}
+	if loc, err := time.LoadLocation(tzid); err == nil {
+		return loc, nil
+	}
+	if loc, ok := r.zones[tzid]; ok {
+		return loc, nil
+	}
+	return nil, ErrICalParse
+}
+
+// times 解析属性中以逗号分隔的时间
+func (r *icalReader) times(p icalProperty) ([]*Carbon, bool, error) {
+	loc, err := r.location(p.params)
+	if err != nil {
+		return nil, false, err
+	}
+	var result []*Carbon
+	date := false
+	for _, v := range strings.Split(p.value, ",") {
+		t, isDate, err := parseICalTime(v, nil, loc)
+		if err != nil {
+			return nil, false, ErrICalParse
+		}
+		result, date = append(result, CreateFromGo(t)), isDate
+	}
+	return result, date, nil
+}
+
+// ParseICalendar 解析 .ics 文本中的 VEVENT，支持 DTSTART、DTEND、DURATION、RRULE、EXDATE，
+// TZID 不是 IANA 名称(如 Outlook 的 "China Standard Time")时按 VTIMEZONE 的定义计算
+func ParseICalendar(data string) ([]*ICalEvent, error) {
+	roots, err := parseICalComponents(data)
+	if err != nil {
+		return nil, err
+	}
+	r := &icalReader{zones: map[string]*time.Location{}}
+	var events []*icalComponent
+	for _, root := range roots {
+		for _, c := range root.children {
+			switch c.name {
+			case "VTIMEZONE":
+				tzid, _ := c.get("TZID")
+				loc, err := vtimezoneLocation(tzid.value, c)
+				if err != nil {
+					return nil, err
+				}
+				r.zones[tzid.value] = loc
+			case "VEVENT":
+				events = append(events, c)
+			}
+		}
+	}
+
+	var result []*ICalEvent
+	for _, c := range events {
+		e := &ICalEvent{}
+		for _, p := range c.properties {
+			switch p.name {
+			case "UID":
+				e.UID = p.value
+			case "SUMMARY":
+				e.Summary = icalTextUnescaper.Replace(p.value)
+			case "DESCRIPTION":
+				e.Description = icalTextUnescaper.Replace(p.value)
+			case "LOCATION":
+				e.Location = icalTextUnescaper.Replace(p.value)
+			case "DTSTAMP", "DTSTART", "DTEND", "EXDATE":
+				times, date, err := r.times(p)
+				if err != nil {
+					return nil, err
+				}
+				switch p.name {
+				case "DTSTAMP":
+					e.Stamp = times[0]
+				case "DTSTART":
+					e.Start, e.AllDay = times[0], date
+				case "DTEND":
+					e.End = times[0]
+				default:
+					e.ExDates = append(e.ExDates, times...)
+				}
+			case "DURATION":
+				if e.Duration, err = ParseICalDuration(p.value); err != nil {
+					return nil, ErrICalParse
+				}
+			}
+		}
+		if e.Start == nil {
+			return nil, ErrICalParse
+		}
+		if p, ok := c.get("RRULE"); ok {
+			if e.RRule, err = parseRRuleValue(p.value, e.Start); err != nil {
+				return nil, err
+			}
+		}
+		if e.End == nil && e.Duration != nil {
+			e.End = CreateFromGo(e.Start.time).AddICalDuration(e.Duration)
+		}
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+// parseICalOffset 解析 VTIMEZONE 的时差，如 "+0800"、"-0430"、"+053000"
+func parseICalOffset(value string) (int, error) {
+	if (len(value) != 5 && len(value) != 7) || value[0] != '+' && value[0] != '-' {
+		return 0, ErrICalParse
+	}
+	n, err := strconv.Atoi(value[1:])
+	if err != nil {
+		return 0, ErrICalParse
+	}
+	if len(value) == 5 {
+		n *= 100
+	}
+	offset := n/10000*3600 + n/100%100*60 + n%100
+	if value[0] == '-' {
+		offset = -offset
+	}
+	return offset, nil
+}
+
+// formatICalOffset 输出 VTIMEZONE 的时差，如 "+0800"
+func formatICalOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	s := sign + pad(offset/3600, 2) + pad(offset%3600/60, 2)
+	if offset%60 != 0 {
+		s += pad(offset%60, 2)
+	}
+	return s
+}
+
+// icalZone 时区的一种时差
+type icalZone struct {
+	offset int
+	dst    bool
+	name   string
+}
+
+// icalTransition 时区的一次调整
+type icalTransition struct {
+	at   int64
+	zone int
+}
+
+// tzifData 生成 TZif 第 2 版数据，第 1 版部分的时刻限制在 int32 范围内，只读取第 1 版的 Go 版本也可以使用
+func tzifData(zones []icalZone, transitions []icalTransition) []byte {
+	var abbrev []byte
+	index := make([]int, len(zones))
+	for i, z := range zones {
+		index[i] = len(abbrev)
+		abbrev = append(append(abbrev, z.name...), 0)
+	}
+	var b bytes.Buffer
+	block := func(wide bool) {
+		b.WriteString("TZif2")
+		b.Write(make([]byte, 15))
+		for _, n := range []int{0, 0, 0, len(transitions), len(zones), len(abbrev)} {
+			binary.Write(&b, binary.BigEndian, uint32(n))
+		}
+		for _, t := range transitions {
+			switch {
+			case wide:
+				binary.Write(&b, binary.BigEndian, t.at)
+			case t.at < math.MinInt32:
+				binary.Write(&b, binary.BigEndian, int32(math.MinInt32))
+			case t.at > math.MaxInt32:
+				binary.Write(&b, binary.BigEndian, int32(math.MaxInt32))
+			default:
+				binary.Write(&b, binary.BigEndian, int32(t.at))
+			}
+		}
+		for _, t := range transitions {
+			b.WriteByte(byte(t.zone))
+		}
+		for i, z := range zones {
+			binary.Write(&b, binary.BigEndian, int32(z.offset))
+			dst := byte(0)
+			if z.dst {
+				dst = 1
+			}
+			b.Write([]byte{dst, byte(index[i])})
+		}
+		b.Write(abbrev)
+	}
+	block(false)
+	block(true)
+	return b.Bytes()
+}
+
+// vtimezoneLocation 由 VTIMEZONE 的 STANDARD、DAYLIGHT 生成时区
+func vtimezoneLocation(tzid string, c *icalComponent) (*time.Location, error) {
+	var zones []icalZone
+	var transitions []icalTransition
+	for _, child := range c.children {
+		if child.name != "STANDARD" && child.name != "DAYLIGHT" {
+			continue
+		}
+		from, _ := child.get("TZOFFSETFROM")
+		to, _ := child.get("TZOFFSETTO")
+		start, _ := child.get("DTSTART")
+		offsetFrom, err := parseICalOffset(from.value)
+		if err != nil {
+			return nil, err
+		}
+		offsetTo, err := parseICalOffset(to.value)
+		if err != nil {
+			return nil, err
+		}
+		name := tzid
+		if p, ok := child.get("TZNAME"); ok {
+			name = p.value
+		}
+		zones = append(zones, icalZone{offsetTo, child.name == "DAYLIGHT", name})
+
+		// DTSTART 是按 TZOFFSETFROM 的墙上时间，按 UTC 展开后减去时差
+		dtstart, err := time.Parse("20060102T150405", start.value)
+		if err != nil {
+			return nil, ErrICalParse
+		}
+		walls := []time.Time{dtstart}
+		if p, ok := child.get("RRULE"); ok {
+			rule, err := parseRRuleValue(p.value, CreateFromGo(dtstart))
+			if err != nil {
+				return nil, err
+			}
+			walls = walls[:0]
+			g := newRRuleGen(rule, nil)
+			for t, ok := g.next(); ok && t.Year() <= icalZoneUntil; t, ok = g.next() {
+				walls = append(walls, t)
+			}
+		}
+		for _, p := range child.properties {
+			if p.name == "RDATE" {
+				for _, v := range strings.Split(p.value, ",") {
+					t, err := time.Parse("20060102T150405", v)
+					if err != nil {
+						return nil, ErrICalParse
+					}
+					walls = append(walls, t)
+				}
+			}
+		}
+		for _, t := range walls {
+			transitions = append(transitions, icalTransition{t.Unix() - int64(offsetFrom), len(zones) - 1})
+		}
+	}
+	if len(zones) == 0 {
+		return nil, ErrICalParse
+	}
+	sort.Slice(transitions, func(i, j int) bool { return transitions[i].at < transitions[j].at })
+
+	loc, err := time.LoadLocationFromTZData(tzid, tzifData(zones, transitions))
+	if err != nil {
+		return nil, ErrICalParse
+	}
+	return loc, nil
+}
+
+// FormatICalendar 输出包含 VEVENT 的 .ics 文本，使用 CRLF 换行，超过 75 字节的行会折行，
+// 非 UTC、非本地时区的时间使用 TZID 并输出按事件开始年份生成的 VTIMEZONE
+func FormatICalendar(events []*ICalEvent) string {
+	var lines []string
+	add := func(name string, params map[string]string, value string) {
+		line := name
+		keys := make([]string, 0, len(params))
+		for k := range params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			v := params[k]
+			if strings.ContainsAny(v, ";:,") {
+				v = `"` + v + `"`
+			}
+			line += ";" + k + "=" + v
+		}
+		lines = append(lines, line+":"+value)
+	}
+	timeValue := func(c *Carbon, date bool) (map[string]string, string) {
+		if date {
+			return map[string]string{"VALUE": "DATE"}, c.ToICalDateString()
+		}
+		tzid, value := icalTimeValue(c.time)
+		if tzid == "" {
+			return nil, value
+		}
+		return map[string]string{"TZID": tzid}, value
+	}
+
+	add("BEGIN", nil, "VCALENDAR")
+	add("VERSION", nil, "2.0")
+	add("PRODID", nil, icalProdID)
+
+	// 每个时区只输出一次
+	seen := map[string]bool{}
+	for _, e := range events {
+		if e.AllDay {
+			continue
+		}
+		for _, c := range append([]*Carbon{e.Start, e.End}, e.ExDates...) {
+			if c == nil {
+				continue
+			}
+			if params, _ := timeValue(c, false); params != nil && !seen[params["TZID"]] {
+				seen[params["TZID"]] = true
+				lines = append(lines, vtimezoneLines(c.time.Location(), e.Start.time.Year())...)
+			}
+		}
+	}
+
+	for _, e := range events {
+		add("BEGIN", nil, "VEVENT")
+		if e.UID != "" {
+			add("UID", nil, e.UID)
+		}
+		stamp := e.Stamp
+		if stamp == nil {
+			stamp = Now()
+		}
+		add("DTSTAMP", nil, stamp.ToICalUTCString())
+		params, value := timeValue(e.Start, e.AllDay)
+		add("DTSTART", params, value)
+		switch {
+		case e.Duration != nil:
+			add("DURATION", nil, e.Duration.String())
+		case e.End != nil:
+			params, value = timeValue(e.End, e.AllDay)
+			add("DTEND", params, value)
+		}
+		if e.RRule != nil {
+			add("RRULE", nil, e.RRule.String())
+		}
+		if len(e.ExDates) > 0 {
+			values := make([]string, len(e.ExDates))
+			for i, c := range e.ExDates {
+				params, values[i] = timeValue(CreateFromGo(c.time.In(e.Start.time.Location())), e.AllDay)
+			}
+			add("EXDATE", params, strings.Join(values, ","))
+		}
+		for _, p := range []struct{ name, value string }{
+			{"SUMMARY", e.Summary}, {"DESCRIPTION", e.Description}, {"LOCATION", e.Location},
+		} {
+			if p.value != "" {
+				add(p.name, nil, icalTextEscaper.Replace(p.value))
+			}
+		}
+		add("END", nil, "VEVENT")
+	}
+	add("END", nil, "VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICalLine(line))
+		b.WriteString("\r\n")
+	}
+	return b.String()
+}
+
+// foldICalLine 按 75 字节折行，不拆分 UTF-8 字符，后续行以空格开头
+func foldICalLine(line string) string {
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		n := utf8.RuneLen(r)
+		if width+n > icalLineLength {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += n
+	}
+	return b.String()
+}
+
+// zoneChanges 返回 loc 在 year 年的时差调整时间
+func zoneChanges(loc *time.Location, year int) []time.Time {
+	var changes []time.Time
+	from := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	end := time.Date(year+1, 1, 1, 0, 0, 0, 0, loc)
+	for t := from; t.Before(end); t = t.Add(24 * time.Hour) {
+		next := t.Add(24 * time.Hour)
+		_, a := t.Zone()
+		_, b := next.Zone()
+		if a == b {
+			continue
+		}
+		// 二分查找调整的时刻
+		lo, hi := t.Unix(), next.Unix()
+		for hi-lo > 1 {
+			mid := (lo + hi) / 2
+			if _, offset := time.Unix(mid, 0).In(loc).Zone(); offset == a {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		changes = append(changes, time.Unix(hi, 0).In(loc))
+	}
+	return changes
+}
+
+// vtimezoneLines 由时区在 year 年的调整生成 VTIMEZONE，每个调整按每年同月的第几个星期几重复
+func vtimezoneLines(loc *time.Location, year int) []string {
+	lines := []string{"BEGIN:VTIMEZONE", "TZID:" + loc.String()}
+	changes := zoneChanges(loc, year)
+	if len(changes) == 0 {
+		name, offset := time.Date(year, 1, 1, 0, 0, 0, 0, loc).Zone()
+		return append(lines,
+			"BEGIN:STANDARD", "DTSTART:19700101T000000",
+			"TZOFFSETFROM:"+formatICalOffset(offset), "TZOFFSETTO:"+formatICalOffset(offset),
+			"TZNAME:"+name, "END:STANDARD", "END:VTIMEZONE")
+	}
+	for _, t := range changes {
+		_, before := t.Add(-time.Second).Zone()
+		name, after := t.Zone()
+		kind := "STANDARD"
+		if after > before {
+			kind = "DAYLIGHT"
+		}
+		// 按调整前的时差表示墙上时间
+		wall := t.UTC().Add(time.Duration(before) * time.Second)
+		n := (wall.Day()-1)/7 + 1
+		if wall.Day()+7 > time.Date(wall.Year(), wall.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day() {
+			n = -1
+		}
+		byDay := RWeekday{wall.Weekday(), n}
+		lines = append(lines,
+			"BEGIN:"+kind, "DTSTART:"+wall.Format("20060102T150405"),
+			"RRULE:FREQ=YEARLY;BYMONTH="+strconv.Itoa(int(wall.Month()))+";BYDAY="+byDay.String(),
+			"TZOFFSETFROM:"+formatICalOffset(before), "TZOFFSETTO:"+formatICalOffset(after),
+			"TZNAME:"+name, "END:"+kind)
+	}
+	return append(lines, "END:VTIMEZONE")
+}
@@ -0,0 +1,209 @@
+package carbon
+
+import (
+	"encoding/binary"
+	"flag"
+	"io/ioutil"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var updateGolden = flag.Bool("update", false, "更新 testdata 中的 golden 文件")
+
+func TestParseICalDateTime(t *testing.T) {
+	as := assert.New(t)
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"20190412", time.Date(2019, 4, 12, 0, 0, 0, 0, shanghai)},
+		{"20190412T090000", time.Date(2019, 4, 12, 9, 0, 0, 0, shanghai)},
+		{"20190412T010000Z", time.Date(2019, 4, 12, 1, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		c, err := ParseICalDateTime(test.value, shanghai)
+		as.Nil(err, test.value)
+		as.True(c.time.Equal(test.want), test.value)
+		as.Equal(test.want.Location(), c.time.Location(), test.value)
+	}
+	for _, value := range []string{"", "2019-04-12", "20190412T0900", "20191312T090000Z"} {
+		_, err := ParseICalDateTime(value, shanghai)
+		as.Equal(ErrTimeParse, err, value)
+	}
+
+	c := CreateFromGo(time.Date(2019, 4, 12, 9, 0, 0, 0, shanghai))
+	as.Equal("20190412", c.ToICalDateString())
+	as.Equal("20190412T090000", c.ToICalDateTimeString())
+	as.Equal("20190412T010000Z", c.ToICalUTCString())
+}
+
+func TestICalDuration(t *testing.T) {
+	as := assert.New(t)
+	tests := []struct {
+		value, want string
+		duration    time.Duration
+	}{
+		{"P1W", "P1W", 7 * 24 * time.Hour},
+		{"P15DT5H0M20S", "P15DT5H0M20S", 15*24*time.Hour + 5*time.Hour + 20*time.Second},
+		{"-PT15M", "-PT15M", -15 * time.Minute},
+		{"+P1D", "P1D", 24 * time.Hour},
+		{"PT0S", "PT0S", 0},
+		{"PT1H0M0S", "PT1H", time.Hour},
+		{"PT2M5S", "PT2M5S", 2*time.Minute + 5*time.Second},
+	}
+	for _, test := range tests {
+		d, err := ParseICalDuration(test.value)
+		as.Nil(err, test.value)
+		as.Equal(test.want, d.String(), test.value)
+		as.Equal(test.duration, d.Duration(), test.value)
+		as.Equal(test.want, NewICalDuration(test.duration).String(), test.value)
+	}
+	as.Equal("P1DT1H30M", NewICalDuration(25*time.Hour+30*time.Minute+500*time.Millisecond).String())
+	for _, value := range []string{"", "P", "PT", "P1H", "P1W2D", "1D", "P-1D", "PT1.5S"} {
+		_, err := ParseICalDuration(value)
+		as.Equal(ErrDurationParse, err, value)
+	}
+
+	// 天按日历相加，跨越夏令时仍为同一时刻
+	ny, _ := time.LoadLocation("America/New_York")
+	d, _ := ParseICalDuration("P1DT1H")
+	c := CreateFromGo(time.Date(2019, 3, 9, 9, 0, 0, 0, ny)).AddICalDuration(d)
+	as.Equal("2019-03-10 10:00:00 EDT", c.Format("2006-01-02 15:04:05 MST"))
+	as.Equal(10, c.Day)
+	as.Equal(10, c.Hour)
+	d.Negative = true
+	as.Equal("2019-03-09 09:00:00 EST", c.AddICalDuration(d).Format("2006-01-02 15:04:05 MST"))
+}
+
+func TestParseICalendar(t *testing.T) {
+	as := assert.New(t)
+	data, err := ioutil.ReadFile("testdata/ical/outlook.ics")
+	as.Nil(err)
+	events, err := ParseICalendar(string(data))
+	as.Nil(err)
+	as.Equal(3, len(events))
+
+	// VTIMEZONE 定义的 Windows 时区
+	weekly := events[0]
+	as.Equal("Weekly sync, team A", weekly.Summary)
+	as.Equal("Agenda:\n1. Status\n2. Risks; blockers and a very long line that needs folding", weekly.Description)
+	as.Equal("Eastern Standard Time", weekly.Start.time.Location().String())
+	as.True(weekly.Start.time.Equal(time.Date(2019, 3, 4, 14, 30, 0, 0, time.UTC)))
+	as.True(weekly.End.time.Equal(time.Date(2019, 3, 4, 15, 0, 0, 0, time.UTC)))
+	as.Equal("FREQ=WEEKLY;COUNT=4;BYDAY=MO", weekly.RRule.String())
+	var got []string
+	it := weekly.RRuleSet().Iterator()
+	for c := it.Next(); c != nil; c = it.Next() {
+		got = append(got, c.time.UTC().Format("2006-01-02 15:04"))
+	}
+	// 2019-03-10 起为夏令时，EXDATE 去掉 03-18
+	as.Equal([]string{"2019-03-04 14:30", "2019-03-11 13:30", "2019-03-25 13:30"}, got)
+
+	allDay := events[1]
+	as.True(allDay.AllDay)
+	as.Equal("清明节假期", allDay.Summary)
+	as.Equal("2019-04-12 00:00:00", allDay.Start.ToDateTimeString())
+	as.Equal("2019-04-13 00:00:00", allDay.End.ToDateTimeString())
+
+	call := events[2]
+	as.Equal("Room 1", call.Location)
+	as.Equal("PT45M", call.Duration.String())
+	as.True(call.Start.time.Equal(time.Date(2019, 4, 12, 1, 0, 0, 0, time.UTC)))
+	as.True(call.End.time.Equal(time.Date(2019, 4, 12, 1, 45, 0, 0, time.UTC)))
+
+	for _, data := range []string{
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:no start\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;TZID=Nowhere:20190412T090000\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:tomorrow\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"SUMMARY:outside\r\n",
+	} {
+		_, err := ParseICalendar(data)
+		as.Equal(ErrICalParse, err, data)
+	}
+}
+
+func TestTZifData(t *testing.T) {
+	as := assert.New(t)
+	zones := []icalZone{{-18000, false, "EST"}, {-14400, true, "EDT"}}
+	transitions := []icalTransition{{-1 << 40, 0}, {1552201200, 1}, {1 << 40, 0}}
+	data := tzifData(zones, transitions)
+
+	// 第 1 版部分有完整数据，超出 int32 的时刻被限制在范围内
+	as.Equal("TZif2", string(data[:5]))
+	as.Equal(uint32(3), binary.BigEndian.Uint32(data[32:]))
+	as.Equal(uint32(2), binary.BigEndian.Uint32(data[36:]))
+	as.Equal(int32(math.MinInt32), int32(binary.BigEndian.Uint32(data[44:])))
+	as.Equal(int32(1552201200), int32(binary.BigEndian.Uint32(data[48:])))
+	as.Equal(int32(math.MaxInt32), int32(binary.BigEndian.Uint32(data[52:])))
+
+	loc, err := time.LoadLocationFromTZData("Eastern", data)
+	as.Nil(err)
+	name, offset := time.Date(2019, 3, 10, 12, 0, 0, 0, time.UTC).In(loc).Zone()
+	as.Equal("EDT", name)
+	as.Equal(-14400, offset)
+}
+
+func TestFormatICalendar(t *testing.T) {
+	as := assert.New(t)
+	ny, _ := time.LoadLocation("America/New_York")
+	stamp := CreateFromGo(time.Date(2019, 4, 1, 12, 0, 0, 0, time.UTC))
+	rule, _ := ParseRRule("FREQ=WEEKLY;COUNT=4;BYDAY=MO")
+	events := []*ICalEvent{
+		{
+			UID:         "weekly-1",
+			Stamp:       stamp,
+			Start:       CreateFromGo(time.Date(2019, 3, 4, 9, 30, 0, 0, ny)),
+			End:         CreateFromGo(time.Date(2019, 3, 4, 10, 0, 0, 0, ny)),
+			RRule:       rule,
+			ExDates:     []*Carbon{CreateFromGo(time.Date(2019, 3, 18, 13, 30, 0, 0, time.UTC))},
+			Summary:     "Weekly sync, team A",
+			Description: "Agenda:\n1. Status\n2. Risks; blockers and a very long line that needs folding",
+		},
+		{
+			UID:     "all-day-1",
+			Stamp:   stamp,
+			Start:   Create(2019, 4, 12, 0, 0, 0, ny),
+			End:     Create(2019, 4, 13, 0, 0, 0, ny),
+			AllDay:  true,
+			Summary: "清明节假期，放假三天，调休安排请以国务院办公厅通知为准，谢谢大家的配合与理解",
+		},
+		{
+			UID:      "call-1",
+			Stamp:    stamp,
+			Start:    CreateFromGo(time.Date(2019, 4, 12, 1, 0, 0, 0, time.UTC)),
+			Duration: NewICalDuration(45 * time.Minute),
+			Location: "Room 1",
+		},
+	}
+	out := FormatICalendar(events)
+	if *updateGolden {
+		as.Nil(ioutil.WriteFile("testdata/ical/export.golden", []byte(out), 0644))
+	}
+	golden, err := ioutil.ReadFile("testdata/ical/export.golden")
+	as.Nil(err)
+	as.Equal(string(golden), out)
+
+	// 导出后再导入
+	parsed, err := ParseICalendar(out)
+	as.Nil(err)
+	as.Equal(len(events), len(parsed))
+	for i, e := range events {
+		if e.AllDay {
+			// DATE 没有时区，按本地时区解析
+			as.Equal(e.Start.ToDateString(), parsed[i].Start.ToDateString(), e.UID)
+		} else {
+			as.True(e.Start.time.Equal(parsed[i].Start.time), e.UID)
+			as.Equal(e.Start.time.Location().String(), parsed[i].Start.time.Location().String(), e.UID)
+		}
+		as.Equal(e.Summary, parsed[i].Summary, e.UID)
+		as.Equal(e.Description, parsed[i].Description, e.UID)
+		as.Equal(e.AllDay, parsed[i].AllDay, e.UID)
+	}
+	as.True(parsed[0].ExDates[0].time.Equal(events[0].ExDates[0].time))
+	as.True(parsed[2].End.time.Equal(time.Date(2019, 4, 12, 1, 45, 0, 0, time.UTC)))
+}
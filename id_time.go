@@ -0,0 +1,188 @@
+package carbon
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	// TwitterEpoch Twitter Snowflake 的起点，毫秒级时间戳
+	TwitterEpoch = 1288834974657
+	// DiscordEpoch Discord Snowflake 的起点，毫秒级时间戳
+	DiscordEpoch = 1420070400000
+	// SnowflakeShift Twitter、Discord Snowflake 中时间戳之后的位数
+	SnowflakeShift = 22
+
+	// uuidGregorianEpoch 1582-10-15 到 1970-01-01 的 100 纳秒数
+	uuidGregorianEpoch = 122192928000000000
+	// ksuidEpoch KSUID 的起点，秒级时间戳
+	ksuidEpoch = 1400000000
+	// ksuidLength KSUID 字符串的长度
+	ksuidLength = 27
+	// crockford ULID 使用的 Crockford Base32 字符
+	crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// parseUUID 解析 UUID 字符串，可以带 "urn:uuid:" 前缀或大括号
+func parseUUID(value string) ([]byte, bool) {
+	s := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(value)), "urn:uuid:")
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+	if len(s) == 36 && s[8] == '-' && s[13] == '-' && s[18] == '-' && s[23] == '-' {
+		s = s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	}
+	b, err := hex.DecodeString(s)
+	return b, err == nil && len(b) == 16
+}
+
+// formatUUID 返回带连字符的 UUID 字符串
+func formatUUID(b []byte) string {
+	s := hex.EncodeToString(b)
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// CreateFromUUID 从 UUID 中解析生成时间，支持版本 1、6(精确到 100 纳秒)及版本 7(精确到毫秒)，
+// 其他版本返回 ErrUUIDVersion
+func CreateFromUUID(value string) (*Carbon, error) {
+	b, ok := parseUUID(value)
+	if !ok {
+		return &Carbon{}, ErrIDParse
+	}
+	var ts int64
+	switch b[6] >> 4 {
+	case 1:
+		ts = int64(binary.BigEndian.Uint16(b[6:])&0x0fff)<<48 | int64(binary.BigEndian.Uint16(b[4:]))<<32 | int64(binary.BigEndian.Uint32(b))
+	case 6:
+		ts = int64(binary.BigEndian.Uint32(b))<<28 | int64(binary.BigEndian.Uint16(b[4:]))<<12 | int64(binary.BigEndian.Uint16(b[6:])&0x0fff)
+	case 7:
+		return CreateFromTimestampMilli(int64(binary.BigEndian.Uint64(b) >> 16)), nil
+	default:
+		return &Carbon{}, ErrUUIDVersion
+	}
+	ts -= uuidGregorianEpoch
+	return CreateFromGo(time.Unix(ts/1e7, ts%1e7*100)), nil
+}
+
+// MinUUID 返回当前时间对应的最小 UUID，其余位均为 0，用于按版本 6、7 的 UUID 范围查询，版本 1 的 UUID 不能按字符串排序
+func (c *Carbon) MinUUID(version int) (string, error) {
+	b := make([]byte, 16)
+	ts := uint64(c.time.Unix()*1e7 + int64(c.time.Nanosecond())/100 + uuidGregorianEpoch)
+	switch version {
+	case 1:
+		binary.BigEndian.PutUint32(b, uint32(ts))
+		binary.BigEndian.PutUint16(b[4:], uint16(ts>>32))
+		binary.BigEndian.PutUint16(b[6:], uint16(ts>>48)&0x0fff)
+	case 6:
+		binary.BigEndian.PutUint32(b, uint32(ts>>28))
+		binary.BigEndian.PutUint16(b[4:], uint16(ts>>12))
+		binary.BigEndian.PutUint16(b[6:], uint16(ts)&0x0fff)
+	case 7:
+		binary.BigEndian.PutUint64(b, uint64(c.TimestampMilli())<<16)
+	default:
+		return "", ErrUUIDVersion
+	}
+	b[6] |= byte(version) << 4
+	// RFC 4122 变体
+	b[8] = 0x80
+	return formatUUID(b), nil
+}
+
+// CreateFromULID 从 ULID 中解析生成时间，精确到毫秒
+func CreateFromULID(value string) (*Carbon, error) {
+	if len(value) != 26 || value[0] > '7' {
+		return &Carbon{}, ErrIDParse
+	}
+	var ms int64
+	for i, r := range strings.ToUpper(value) {
+		d := strings.IndexRune(crockford, r)
+		if d < 0 {
+			return &Carbon{}, ErrIDParse
+		}
+		// 前 10 个字符为时间戳
+		if i < 10 {
+			ms = ms<<5 | int64(d)
+		}
+	}
+	return CreateFromTimestampMilli(ms), nil
+}
+
+// MinULID 返回当前时间对应的最小 ULID，随机部分均为 0
+func (c *Carbon) MinULID() string {
+	ms := c.TimestampMilli()
+	b := make([]byte, 26)
+	for i := 9; i >= 0; i-- {
+		b[i] = crockford[ms&31]
+		ms >>= 5
+	}
+	for i := 10; i < 26; i++ {
+		b[i] = '0'
+	}
+	return string(b)
+}
+
+// swapCase 交换大小写，math/big 的 62 进制小写字母在前，KSUID 大写字母在前
+func swapCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		}
+		return r
+	}, s)
+}
+
+// CreateFromKSUID 从 KSUID 中解析生成时间，精确到秒
+func CreateFromKSUID(value string) (*Carbon, error) {
+	n, ok := new(big.Int).SetString(swapCase(value), 62)
+	if len(value) != ksuidLength || !ok || n.Sign() < 0 || n.BitLen() > 160 {
+		return &Carbon{}, ErrIDParse
+	}
+	return CreateFromTimestamp(n.Rsh(n, 128).Int64() + ksuidEpoch), nil
+}
+
+// MinKSUID 返回当前时间对应的最小 KSUID，随机部分均为 0，
+// 时间戳限制在 KSUID 的范围内，早于 2014-05-13 时返回全 0，晚于 2150-06-19 时按 2150-06-19 计算
+func (c *Carbon) MinKSUID() string {
+	ts := c.time.Unix() - ksuidEpoch
+	switch {
+	case ts < 0:
+		ts = 0
+	case ts > math.MaxUint32:
+		ts = math.MaxUint32
+	}
+	n := new(big.Int).Lsh(big.NewInt(ts), 128)
+	s := swapCase(n.Text(62))
+	return strings.Repeat("0", ksuidLength-len(s)) + s
+}
+
+// CreateFromObjectID 从 MongoDB ObjectID 中解析生成时间，精确到秒
+func CreateFromObjectID(value string) (*Carbon, error) {
+	b, err := hex.DecodeString(value)
+	if err != nil || len(b) != 12 {
+		return &Carbon{}, ErrIDParse
+	}
+	return CreateFromTimestamp(int64(binary.BigEndian.Uint32(b))), nil
+}
+
+// MinObjectID 返回当前时间对应的最小 MongoDB ObjectID，其余部分均为 0
+func (c *Carbon) MinObjectID() string {
+	b := make([]byte, 12)
+	binary.BigEndian.PutUint32(b, uint32(c.time.Unix()))
+	return hex.EncodeToString(b)
+}
+
+// CreateFromSnowflake 从 Snowflake ID 中解析生成时间，epoch 为起点的毫秒级时间戳，shift 为时间戳之后的位数，
+// 如 Twitter 为 TwitterEpoch、SnowflakeShift，Discord 为 DiscordEpoch、SnowflakeShift
+func CreateFromSnowflake(id, epoch int64, shift uint) *Carbon {
+	return CreateFromTimestampMilli(int64(uint64(id)>>shift) + epoch)
+}
+
+// MinSnowflake 返回当前时间对应的最小 Snowflake ID，其余部分均为 0
+func (c *Carbon) MinSnowflake(epoch int64, shift uint) int64 {
+	return (c.TimestampMilli() - epoch) << shift
+}
@@ -0,0 +1,134 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateFromUUID(t *testing.T) {
+	as := assert.New(t)
+	want := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	for _, value := range []string{
+		"C232AB00-9414-11EC-B3C8-9F6BDECED846",
+		"1EC9414C-232A-6B00-B3C8-9F6BDECED846",
+		"017F22E2-79B0-7CC3-98C4-DC0C0C07398F",
+		"urn:uuid:017f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+		"{017f22e279b07cc398c4dc0c0c07398f}",
+	} {
+		c, err := CreateFromUUID(value)
+		as.Nil(err, value)
+		as.True(c.time.Equal(want), value)
+	}
+
+	_, err := CreateFromUUID("550e8400-e29b-41d4-a716-446655440000")
+	as.Equal(ErrUUIDVersion, err)
+	for _, value := range []string{"", "017F22E2-79B0-7CC3-98C4", "017F22E2-79B0-7CC3-98C4-DC0C0C07398G"} {
+		_, err := CreateFromUUID(value)
+		as.Equal(ErrIDParse, err, value)
+	}
+}
+
+func TestCarbon_MinUUID(t *testing.T) {
+	as := assert.New(t)
+	c := CreateFromGo(time.Date(2022, 2, 22, 19, 22, 22, 123456700, time.UTC))
+	tests := []struct {
+		version int
+		want    string
+	}{
+		{1, "c2458187-9414-11ec-8000-000000000000"},
+		{6, "1ec9414c-2458-6187-8000-000000000000"},
+		{7, "017f22e2-7a2b-7000-8000-000000000000"},
+	}
+	for _, test := range tests {
+		s, err := c.MinUUID(test.version)
+		as.Nil(err)
+		as.Equal(test.want, s)
+		parsed, err := CreateFromUUID(s)
+		as.Nil(err)
+		if test.version == 7 {
+			as.True(parsed.time.Equal(c.time.Truncate(time.Millisecond)))
+		} else {
+			as.True(parsed.time.Equal(c.time))
+		}
+	}
+	_, err := c.MinUUID(4)
+	as.Equal(ErrUUIDVersion, err)
+
+	// 版本 7 按字符串排序即按时间排序
+	earlier, _ := CreateFromGo(c.time.Add(-time.Millisecond)).MinUUID(7)
+	later, _ := c.MinUUID(7)
+	as.True(earlier < later)
+}
+
+func TestCreateFromULID(t *testing.T) {
+	as := assert.New(t)
+	c, err := CreateFromULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	as.Nil(err)
+	as.Equal(int64(1469922850259), c.TimestampMilli())
+	c, err = CreateFromULID("01arz3ndektsv4rrffq69g5fav")
+	as.Nil(err)
+	as.Equal(int64(1469922850259), c.TimestampMilli())
+
+	as.Equal("01ARZ3NDEK0000000000000000", c.MinULID())
+	parsed, err := CreateFromULID(c.MinULID())
+	as.Nil(err)
+	as.True(parsed.time.Equal(c.time))
+
+	for _, value := range []string{"", "01ARZ3NDEKTSV4RRFFQ69G5FA", "81ARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAU"} {
+		_, err := CreateFromULID(value)
+		as.Equal(ErrIDParse, err, value)
+	}
+}
+
+func TestCreateFromKSUID(t *testing.T) {
+	as := assert.New(t)
+	c, err := CreateFromKSUID("0ujtsYcgvSTl8PAuAdqWYSMnLOv")
+	as.Nil(err)
+	as.True(c.time.Equal(time.Date(2017, 10, 10, 4, 0, 47, 0, time.UTC)))
+
+	min := c.MinKSUID()
+	as.Equal(27, len(min))
+	as.True(min <= "0ujtsYcgvSTl8PAuAdqWYSMnLOv")
+	parsed, err := CreateFromKSUID(min)
+	as.Nil(err)
+	as.True(parsed.time.Equal(c.time))
+	next, _ := CreateFromKSUID(CreateFromGo(c.time.Add(time.Second)).MinKSUID())
+	as.True(next.time.Equal(c.time.Add(time.Second)))
+	// 超出 KSUID 范围时限制在起点、终点
+	as.Equal("000000000000000000000000000", Create(2000, 1, 1, 0, 0, 0, time.UTC).MinKSUID())
+	last, err := CreateFromKSUID(Create(2200, 1, 1, 0, 0, 0, time.UTC).MinKSUID())
+	as.Nil(err)
+	as.True(last.time.Equal(time.Date(2150, 6, 19, 23, 21, 35, 0, time.UTC)))
+
+	for _, value := range []string{"", "0ujtsYcgvSTl8PAuAdqWYSMnLO", "0ujtsYcgvSTl8PAuAdqWYSMnLO-", "zzzzzzzzzzzzzzzzzzzzzzzzzzz"} {
+		_, err := CreateFromKSUID(value)
+		as.Equal(ErrIDParse, err, value)
+	}
+}
+
+func TestCreateFromObjectID(t *testing.T) {
+	as := assert.New(t)
+	c, err := CreateFromObjectID("507f1f77bcf86cd799439011")
+	as.Nil(err)
+	as.True(c.time.Equal(time.Date(2012, 10, 17, 21, 13, 27, 0, time.UTC)))
+	as.Equal("507f1f770000000000000000", c.MinObjectID())
+
+	for _, value := range []string{"", "507f1f77bcf86cd79943901", "507f1f77bcf86cd79943901z"} {
+		_, err := CreateFromObjectID(value)
+		as.Equal(ErrIDParse, err, value)
+	}
+}
+
+func TestCreateFromSnowflake(t *testing.T) {
+	as := assert.New(t)
+	c := CreateFromSnowflake(175928847299117063, DiscordEpoch, SnowflakeShift)
+	as.True(c.time.Equal(time.Date(2016, 4, 30, 11, 18, 25, 796000000, time.UTC)))
+	as.Equal(int64(175928847298985984), c.MinSnowflake(DiscordEpoch, SnowflakeShift))
+
+	twitter := CreateFromGo(time.Date(2019, 4, 12, 7, 4, 5, 0, time.UTC))
+	id := twitter.MinSnowflake(TwitterEpoch, SnowflakeShift)
+	as.True(CreateFromSnowflake(id, TwitterEpoch, SnowflakeShift).time.Equal(twitter.time))
+	as.True(CreateFromSnowflake(id|(1<<22-1), TwitterEpoch, SnowflakeShift).time.Equal(twitter.time))
+}
@@ -0,0 +1,626 @@
+package carbon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Locale 语言数据，可以从 JSON、YAML 文件加载并通过 RegisterLocale 注册。
+// 缺少的数据按回退链从其他语言获取，如 "zh-TW" 依次回退到 "zh"、"en"
+type Locale struct {
+	// Name 语言名称，如 "zh-TW"
+	Name string `json:"name" yaml:"name"`
+	// Parent 回退的语言，为空时回退到去掉最后一段的名称，如 "zh-TW" 回退到 "zh"，最终回退到 "en"
+	Parent string `json:"parent,omitempty" yaml:"parent,omitempty"`
+	// Plural 使用哪种语言的 CLDR 复数规则，如 "ru"，为空时使用 Name 的语言部分
+	Plural string `json:"plural,omitempty" yaml:"plural,omitempty"`
+	// Units 单位名称，key 为 year、month、week、day、hour、minute、second、millisecond、microsecond、nanosecond，
+	// 值为 CLDR 复数类别 zero、one、two、few、many、other 及简写 short 的模板，模板中的 %d 替换为数值
+	Units map[string]map[string]string `json:"units" yaml:"units"`
+	// RelativeUnits Ago、FromNow 中与 Units 不同的单位模板，格式同 Units，如德语 "vor 3 Tagen" 的与格 {"day": {"other": "%d Tagen"}}
+	RelativeUnits map[string]map[string]string `json:"relativeUnits,omitempty" yaml:"relativeUnits,omitempty"`
+	// Relative 相对时间的模板
+	Relative LocaleRelative `json:"relative" yaml:"relative"`
+	// Months 月份名称
+	Months []string `json:"months" yaml:"months"`
+	// ShortMonths 月份简称
+	ShortMonths []string `json:"shortMonths" yaml:"shortMonths"`
+	// Weekdays 星期名称，从星期日开始
+	Weekdays []string `json:"weekdays" yaml:"weekdays"`
+	// ShortWeekdays 星期简称
+	ShortWeekdays []string `json:"shortWeekdays" yaml:"shortWeekdays"`
+	// MinWeekdays 星期最短的简称
+	MinWeekdays []string `json:"minWeekdays" yaml:"minWeekdays"`
+	// Meridiem 上午、下午
+	Meridiem []string `json:"meridiem" yaml:"meridiem"`
+	// Ordinal 序数词模板，key 为 CLDR 序数类别，如英文的 {"one": "%dst", "two": "%dnd", "few": "%drd", "other": "%dth"}
+	Ordinal map[string]string `json:"ordinal" yaml:"ordinal"`
+	// Calendar Calendar 使用的模板
+	Calendar LocaleCalendar `json:"calendar" yaml:"calendar"`
+	// FirstDayOfWeek 每周的第一天，如 "monday"
+	FirstDayOfWeek string `json:"firstDayOfWeek" yaml:"firstDayOfWeek"`
+	// Formats 默认的日期格式
+	Formats LocaleFormats `json:"formats" yaml:"formats"`
+}
+
+// LocaleRelative 相对时间的模板，见 DiffFormats。分隔符为 nil 时回退到其他语言，可以设置为空字符串
+type LocaleRelative struct {
+	Ago           string  `json:"ago" yaml:"ago"`
+	FromNow       string  `json:"fromNow" yaml:"fromNow"`
+	Before        string  `json:"before" yaml:"before"`
+	After         string  `json:"after" yaml:"after"`
+	JustNow       string  `json:"justNow" yaml:"justNow"`
+	Separator     *string `json:"separator" yaml:"separator"`
+	ListSeparator *string `json:"listSeparator" yaml:"listSeparator"`
+	LastSeparator *string `json:"lastSeparator" yaml:"lastSeparator"`
+}
+
+// LocaleCalendar Calendar 使用的模板，见 CalendarFormats。Weekdays 为空时使用 Locale.Weekdays
+type LocaleCalendar struct {
+	SameDay    string   `json:"sameDay" yaml:"sameDay"`
+	NextDay    string   `json:"nextDay" yaml:"nextDay"`
+	LastDay    string   `json:"lastDay" yaml:"lastDay"`
+	NextWeek   string   `json:"nextWeek" yaml:"nextWeek"`
+	LastWeek   string   `json:"lastWeek" yaml:"lastWeek"`
+	ThisWeek   string   `json:"thisWeek,omitempty" yaml:"thisWeek,omitempty"`
+	SameElse   string   `json:"sameElse" yaml:"sameElse"`
+	TimeLayout string   `json:"timeLayout" yaml:"timeLayout"`
+	DateLayout string   `json:"dateLayout" yaml:"dateLayout"`
+	Weekdays   []string `json:"weekdays,omitempty" yaml:"weekdays,omitempty"`
+}
+
+// LocaleFormats 默认的日期格式，使用 time.Format 的布局
+type LocaleFormats struct {
+	// FormattedDate ToFormattedDateString 使用的格式
+	FormattedDate string `json:"formattedDate" yaml:"formattedDate"`
+	// DayDateTime ToDayDateTimeString 使用的格式
+	DayDateTime string `json:"dayDateTime" yaml:"dayDateTime"`
+}
+
+// LocaleError 语言数据校验错误
+type LocaleError struct {
+	// Locale 语言名称
+	Locale string
+	// Missing 缺少的数据，如 "units.day.one"、"relative.ago"
+	Missing []string
+	// Invalid 错误的数据，如 "months: want 12 items, got 11"
+	Invalid []string
+}
+
+// Error 实现 error 接口
+func (e *LocaleError) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Invalid) > 0 {
+		parts = append(parts, "invalid "+strings.Join(e.Invalid, "; "))
+	}
+	return fmt.Sprintf("locale %q: %s", e.Locale, strings.Join(parts, "; "))
+}
+
+// localeUnits Locale.Units 的 key 及对应的单位
+var localeUnits = []struct {
+	name string
+	unit Unit
+}{
+	{"year", Year}, {"month", Month}, {"week", Week}, {"day", Day}, {"hour", Hour}, {"minute", Minute},
+	{"second", Second}, {"millisecond", Millisecond}, {"microsecond", Microsecond}, {"nanosecond", Nanosecond},
+}
+
+// pluralRule CLDR 复数规则，categories 为该规则使用的类别
+type pluralRule struct {
+	categories []string
+	category   func(n int) string
+}
+
+var (
+	pluralOneOther = pluralRule{[]string{"one", "other"}, func(n int) string {
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	}}
+	pluralZeroOneOther = pluralRule{[]string{"one", "other"}, func(n int) string {
+		if n == 0 || n == 1 {
+			return "one"
+		}
+		return "other"
+	}}
+	pluralOther = pluralRule{[]string{"other"}, func(int) string {
+		return "other"
+	}}
+	pluralSlavic = pluralRule{[]string{"one", "few", "many"}, func(n int) string {
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return "one"
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return "few"
+		}
+		return "many"
+	}}
+	pluralPolish = pluralRule{[]string{"one", "few", "many"}, func(n int) string {
+		switch {
+		case n == 1:
+			return "one"
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return "few"
+		}
+		return "many"
+	}}
+	pluralCzech = pluralRule{[]string{"one", "few", "other"}, func(n int) string {
+		switch {
+		case n == 1:
+			return "one"
+		case n >= 2 && n <= 4:
+			return "few"
+		}
+		return "other"
+	}}
+	pluralArabic = pluralRule{[]string{"zero", "one", "two", "few", "many", "other"}, func(n int) string {
+		switch {
+		case n == 0:
+			return "zero"
+		case n == 1:
+			return "one"
+		case n == 2:
+			return "two"
+		case n%100 >= 3 && n%100 <= 10:
+			return "few"
+		case n%100 >= 11:
+			return "many"
+		}
+		return "other"
+	}}
+
+	// pluralRules 各语言的 CLDR 复数规则(仅整数)，未列出的语言使用 one、other
+	pluralRules = map[string]pluralRule{
+		"en": pluralOneOther, "de": pluralOneOther, "es": pluralOneOther, "it": pluralOneOther,
+		"nl": pluralOneOther, "sv": pluralOneOther, "da": pluralOneOther, "nb": pluralOneOther,
+		"fr": pluralZeroOneOther, "pt": pluralZeroOneOther,
+		"zh": pluralOther, "ja": pluralOther, "ko": pluralOther, "vi": pluralOther,
+		"th": pluralOther, "id": pluralOther, "ms": pluralOther,
+		"ru": pluralSlavic, "uk": pluralSlavic, "be": pluralSlavic,
+		"pl": pluralPolish, "cs": pluralCzech, "sk": pluralCzech,
+		"ar": pluralArabic,
+	}
+
+	// ordinalRules 各语言的 CLDR 序数规则，未列出的语言只使用 other
+	ordinalRules = map[string]pluralRule{
+		"en": {[]string{"one", "two", "few", "other"}, func(n int) string {
+			switch {
+			case n%10 == 1 && n%100 != 11:
+				return "one"
+			case n%10 == 2 && n%100 != 12:
+				return "two"
+			case n%10 == 3 && n%100 != 13:
+				return "few"
+			}
+			return "other"
+		}},
+		"fr": {[]string{"one", "other"}, func(n int) string {
+			if n == 1 {
+				return "one"
+			}
+			return "other"
+		}},
+	}
+)
+
+// language 返回语言名称的语言部分，如 "zh-TW" 返回 "zh"
+func language(name string) string {
+	if i := strings.IndexAny(name, "-_"); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// compiledLocale 由 Locale 生成的各功能使用的数据
+type compiledLocale struct {
+	locale   *Locale
+	diff     *DiffFormats
+	calendar *CalendarFormats
+	date     *DateFormats
+}
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]*Locale{}
+	compiled  = map[string]*compiledLocale{}
+)
+
+// RegisterLocale 注册或替换语言，缺少的数据按回退链从其他语言获取，名称为空或数据格式错误时返回 *LocaleError
+func RegisterLocale(l *Locale) error {
+	if l.Name == "" {
+		return &LocaleError{Missing: []string{"name"}}
+	}
+	if err := l.Validate(); err != nil && len(err.(*LocaleError).Invalid) > 0 {
+		return &LocaleError{Locale: l.Name, Invalid: err.(*LocaleError).Invalid}
+	}
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[l.Name] = l
+	// 回退链上的语言可能变化，清空所有缓存
+	compiled = map[string]*compiledLocale{}
+	return nil
+}
+
+// Locales 返回已注册的语言名称
+func Locales() []string {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	names := make([]string, 0, len(locales))
+	for name := range locales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LocaleFallbacks 返回语言的回退链，如 "zh-TW" 返回 ["zh-TW", "zh", "en"]
+func LocaleFallbacks(name string) []string {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	return localeChain(name)
+}
+
+// localeChain 返回语言的回退链，调用前需持有 localesMu
+func localeChain(name string) []string {
+	var chain []string
+	seen := map[string]bool{}
+	for name != "" && !seen[name] {
+		seen[name] = true
+		chain = append(chain, name)
+		if l, ok := locales[name]; ok && l.Parent != "" {
+			name = l.Parent
+			continue
+		}
+		if i := strings.LastIndexAny(name, "-_"); i >= 0 {
+			name = name[:i]
+		} else {
+			name = ""
+		}
+	}
+	if !seen["en"] {
+		chain = append(chain, "en")
+	}
+	return chain
+}
+
+// ResolveLocale 返回按回退链合并后的语言数据，name 及其回退链上的语言都未注册时返回 "en"
+func ResolveLocale(name string) *Locale {
+	return compiledLocaleOf(name).locale
+}
+
+// compiledLocaleOf 返回回退链上第一个已注册语言生成的数据
+func compiledLocaleOf(name string) *compiledLocale {
+	localesMu.RLock()
+	chain := localeChain(name)
+	for _, n := range chain {
+		if c, ok := compiled[n]; ok {
+			localesMu.RUnlock()
+			return c
+		}
+		if _, ok := locales[n]; ok {
+			name = n
+			break
+		}
+	}
+	localesMu.RUnlock()
+
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	if c, ok := compiled[name]; ok {
+		return c
+	}
+	if _, ok := locales[name]; !ok {
+		name = "en"
+	}
+	c := compileLocale(resolveLocale(name))
+	compiled[name] = c
+	return c
+}
+
+// resolveLocale 按回退链合并语言数据，调用前需持有 localesMu
+func resolveLocale(name string) *Locale {
+	resolved := &Locale{Name: name, Parent: locales[name].Parent}
+	for _, n := range localeChain(name) {
+		l, ok := locales[n]
+		if !ok {
+			continue
+		}
+		if resolved.Plural == "" {
+			if l.Plural != "" {
+				resolved.Plural = l.Plural
+			} else if _, ok := pluralRules[language(n)]; ok {
+				resolved.Plural = language(n)
+			}
+		}
+		mergeValue(reflect.ValueOf(resolved).Elem(), reflect.ValueOf(l).Elem())
+	}
+	return resolved
+}
+
+// mergeValue 将 src 中 dst 缺少的数据复制到 dst，map 按 key 合并
+func mergeValue(dst, src reflect.Value) {
+	switch dst.Kind() {
+	case reflect.Struct:
+		for i := 0; i < dst.NumField(); i++ {
+			if name := dst.Type().Field(i).Name; name != "Name" && name != "Parent" && name != "Plural" {
+				mergeValue(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMap(dst.Type()))
+		}
+		for _, key := range src.MapKeys() {
+			value := src.MapIndex(key)
+			if value.Kind() == reflect.Map {
+				// 复制一份，避免修改已注册的语言数据
+				merged := reflect.MakeMap(value.Type())
+				if old := dst.MapIndex(key); old.IsValid() {
+					mergeValue(merged, old)
+				}
+				mergeValue(merged, value)
+				dst.SetMapIndex(key, merged)
+			} else if !dst.MapIndex(key).IsValid() {
+				dst.SetMapIndex(key, value)
+			}
+		}
+	case reflect.Slice, reflect.String:
+		if dst.Len() == 0 {
+			dst.Set(src)
+		}
+	case reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(src)
+		}
+	}
+}
+
+// ruleOf 返回 rules 中 name 的规则，不存在时返回 def
+func ruleOf(rules map[string]pluralRule, name string, def pluralRule) pluralRule {
+	if r, ok := rules[name]; ok {
+		return r
+	}
+	return def
+}
+
+// parseWeekday 解析英文星期名称，如 "monday"
+func parseWeekday(name string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(name, d.String()) {
+			return d, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// deref 返回指针指向的字符串，为 nil 时返回空字符串
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// compileLocale 由合并后的语言数据生成各功能使用的数据
+func compileLocale(l *Locale) *compiledLocale {
+	plural := ruleOf(pluralRules, l.Plural, pluralOneOther)
+	diff := &DiffFormats{
+		Units:           map[Unit][3]string{},
+		Plurals:         map[Unit]map[string]string{},
+		RelativePlurals: map[Unit]map[string]string{},
+		PluralRule:      plural.category,
+		Ago:             l.Relative.Ago,
+		FromNow:         l.Relative.FromNow,
+		Before:          l.Relative.Before,
+		After:           l.Relative.After,
+		JustNow:         l.Relative.JustNow,
+		Separator:       deref(l.Relative.Separator),
+		ListSeparator:   deref(l.Relative.ListSeparator),
+		LastSeparator:   deref(l.Relative.LastSeparator),
+	}
+	for _, u := range localeUnits {
+		names := l.Units[u.name]
+		other := names["other"]
+		one, short := names["one"], names["short"]
+		if one == "" {
+			one = other
+		}
+		if short == "" {
+			short = other
+		}
+		diff.Units[u.unit] = [3]string{one, other, short}
+		diff.Plurals[u.unit] = names
+		if relative, ok := l.RelativeUnits[u.name]; ok {
+			diff.RelativePlurals[u.unit] = relative
+		}
+	}
+
+	weekStart, _ := parseWeekday(l.FirstDayOfWeek)
+	calendar := &CalendarFormats{
+		SameDay:    l.Calendar.SameDay,
+		NextDay:    l.Calendar.NextDay,
+		LastDay:    l.Calendar.LastDay,
+		NextWeek:   l.Calendar.NextWeek,
+		LastWeek:   l.Calendar.LastWeek,
+		ThisWeek:   l.Calendar.ThisWeek,
+		SameElse:   l.Calendar.SameElse,
+		TimeLayout: l.Calendar.TimeLayout,
+		DateLayout: l.Calendar.DateLayout,
+		WeekStart:  weekStart,
+	}
+	weekdays := l.Calendar.Weekdays
+	if len(weekdays) == 0 {
+		weekdays = l.Weekdays
+	}
+	copy(calendar.Weekdays[:], weekdays)
+
+	ordinal, templates := ruleOf(ordinalRules, language(l.Name), pluralOther), l.Ordinal
+	date := &DateFormats{
+		Ordinal: func(n int) string {
+			tpl, ok := templates[ordinal.category(n)]
+			if !ok {
+				tpl = templates["other"]
+			}
+			return strings.Replace(tpl, "%d", strconv.Itoa(n), 1)
+		},
+		FormattedDate: l.Formats.FormattedDate,
+		DayDateTime:   l.Formats.DayDateTime,
+	}
+	copy(date.Months[:], l.Months)
+	copy(date.ShortMonths[:], l.ShortMonths)
+	copy(date.Weekdays[:], l.Weekdays)
+	copy(date.ShortWeekdays[:], l.ShortWeekdays)
+	copy(date.MinWeekdays[:], l.MinWeekdays)
+	copy(date.Meridiem[:], l.Meridiem)
+
+	return &compiledLocale{locale: l, diff: diff, calendar: calendar, date: date}
+}
+
+// localeOptional 可以缺少的数据
+var localeOptional = map[string]bool{
+	"parent": true, "plural": true, "calendar.thisWeek": true, "calendar.weekdays": true,
+}
+
+// localeLengths 列表数据的长度
+var localeLengths = map[string]int{
+	"months": 12, "shortMonths": 12, "weekdays": 7, "shortWeekdays": 7, "minWeekdays": 7,
+	"meridiem": 2, "calendar.weekdays": 7,
+}
+
+// Validate 校验语言数据，返回 *LocaleError 列出缺少及错误的数据，缺少的数据注册后会按回退链从其他语言获取
+func (l *Locale) Validate() error {
+	e := &LocaleError{Locale: l.Name}
+	validateValue(e, "", reflect.ValueOf(l).Elem())
+
+	plural := ruleOf(pluralRules, l.Plural, pluralOneOther)
+	if l.Plural == "" {
+		plural = ruleOf(pluralRules, language(l.Name), pluralOneOther)
+	}
+	known := map[string]bool{"short": true}
+	for _, u := range localeUnits {
+		known[u.name] = true
+		for _, category := range append(plural.categories, "short") {
+			tpl, ok := l.Units[u.name][category]
+			switch {
+			case !ok:
+				e.Missing = append(e.Missing, "units."+u.name+"."+category)
+			case !strings.Contains(tpl, "%d"):
+				e.Invalid = append(e.Invalid, fmt.Sprintf("units.%s.%s: %q has no %%d", u.name, category, tpl))
+			}
+		}
+	}
+	for name := range l.Units {
+		if !known[name] {
+			e.Invalid = append(e.Invalid, "units."+name+": unknown unit")
+		}
+	}
+
+	ordinal := ruleOf(ordinalRules, language(l.Name), pluralOther)
+	for _, category := range ordinal.categories {
+		tpl, ok := l.Ordinal[category]
+		switch {
+		case !ok:
+			e.Missing = append(e.Missing, "ordinal."+category)
+		case !strings.Contains(tpl, "%d"):
+			e.Invalid = append(e.Invalid, fmt.Sprintf("ordinal.%s: %q has no %%d", category, tpl))
+		}
+	}
+
+	for key, tpl := range map[string]string{
+		"relative.ago": l.Relative.Ago, "relative.fromNow": l.Relative.FromNow,
+		"relative.before": l.Relative.Before, "relative.after": l.Relative.After,
+	} {
+		if tpl != "" && !strings.Contains(tpl, "%s") {
+			e.Invalid = append(e.Invalid, fmt.Sprintf("%s: %q has no %%s", key, tpl))
+		}
+	}
+	if _, ok := parseWeekday(l.FirstDayOfWeek); l.FirstDayOfWeek != "" && !ok {
+		e.Invalid = append(e.Invalid, fmt.Sprintf("firstDayOfWeek: unknown weekday %q", l.FirstDayOfWeek))
+	}
+
+	if len(e.Missing) == 0 && len(e.Invalid) == 0 {
+		return nil
+	}
+	sort.Strings(e.Missing)
+	sort.Strings(e.Invalid)
+	return e
+}
+
+// validateValue 按 json 标签名检查缺少的字符串、列表及列表长度，map 由 Validate 单独检查
+func validateValue(e *LocaleError, prefix string, v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		key := prefix + strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0]
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.Struct:
+			validateValue(e, key+".", field)
+		case reflect.Ptr:
+			if field.IsNil() {
+				e.Missing = append(e.Missing, key)
+			}
+		case reflect.String, reflect.Slice:
+			if field.Len() == 0 {
+				if !localeOptional[key] {
+					e.Missing = append(e.Missing, key)
+				}
+			} else if n, ok := localeLengths[key]; ok && field.Len() != n {
+				e.Invalid = append(e.Invalid, fmt.Sprintf("%s: want %d items, got %d", key, n, field.Len()))
+			}
+		}
+	}
+}
+
+// LoadLocale 解析 JSON 或 YAML 格式的语言数据
+func LoadLocale(data []byte) (*Locale, error) {
+	l := &Locale{}
+	var err error
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		// 与 YAML 一样拒绝未知的字段，Unmarshal 到 RawMessage 检查末尾多余的数据
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err = dec.Decode(l); err == nil {
+			err = json.Unmarshal(data, new(json.RawMessage))
+		}
+	} else {
+		err = yaml.UnmarshalStrict(data, l)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// LoadLocaleFile 从 JSON 或 YAML 文件加载语言数据，数据中没有 name 时使用文件名，如 "zh-TW.yaml" 为 "zh-TW"
+func LoadLocaleFile(path string) (*Locale, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	l, err := LoadLocale(data)
+	if err != nil {
+		return nil, err
+	}
+	if l.Name == "" {
+		base := filepath.Base(path)
+		l.Name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	return l, nil
+}
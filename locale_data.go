@@ -0,0 +1,382 @@
+package carbon
+
+// strPtr 返回字符串的指针
+func strPtr(s string) *string {
+	return &s
+}
+
+// builtinLocales 内置的语言数据
+var builtinLocales = []*Locale{
+	{
+		Name: "en",
+		Units: map[string]map[string]string{
+			"year":        {"one": "%d year", "other": "%d years", "short": "%dy"},
+			"month":       {"one": "%d month", "other": "%d months", "short": "%dmo"},
+			"week":        {"one": "%d week", "other": "%d weeks", "short": "%dw"},
+			"day":         {"one": "%d day", "other": "%d days", "short": "%dd"},
+			"hour":        {"one": "%d hour", "other": "%d hours", "short": "%dh"},
+			"minute":      {"one": "%d minute", "other": "%d minutes", "short": "%dm"},
+			"second":      {"one": "%d second", "other": "%d seconds", "short": "%ds"},
+			"millisecond": {"one": "%d millisecond", "other": "%d milliseconds", "short": "%dms"},
+			"microsecond": {"one": "%d microsecond", "other": "%d microseconds", "short": "%dµs"},
+			"nanosecond":  {"one": "%d nanosecond", "other": "%d nanoseconds", "short": "%dns"},
+		},
+		Relative: LocaleRelative{
+			Ago:           "%s ago",
+			FromNow:       "%s from now",
+			Before:        "%s before",
+			After:         "%s after",
+			JustNow:       "just now",
+			Separator:     strPtr(" "),
+			ListSeparator: strPtr(", "),
+			LastSeparator: strPtr(" and "),
+		},
+		Months:        []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		ShortMonths:   []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Weekdays:      []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		ShortWeekdays: []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		MinWeekdays:   []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+		Meridiem:      []string{"AM", "PM"},
+		Ordinal:       map[string]string{"one": "%dst", "two": "%dnd", "few": "%drd", "other": "%dth"},
+		Calendar: LocaleCalendar{
+			SameDay:    "Today at {time}",
+			NextDay:    "Tomorrow at {time}",
+			LastDay:    "Yesterday at {time}",
+			NextWeek:   "{weekday} at {time}",
+			LastWeek:   "Last {weekday} at {time}",
+			SameElse:   "{date}",
+			TimeLayout: "3:04 PM",
+			DateLayout: "01/02/2006",
+		},
+		FirstDayOfWeek: "sunday",
+		Formats: LocaleFormats{
+			FormattedDate: "Jan 02,2006",
+			DayDateTime:   "Mon, Jan 2, 2006 3:04 PM",
+		},
+	},
+	{
+		Name: "zh",
+		Units: map[string]map[string]string{
+			"year":        {"other": "%d年", "short": "%d年"},
+			"month":       {"other": "%d个月", "short": "%d个月"},
+			"week":        {"other": "%d周", "short": "%d周"},
+			"day":         {"other": "%d天", "short": "%d天"},
+			"hour":        {"other": "%d小时", "short": "%d小时"},
+			"minute":      {"other": "%d分钟", "short": "%d分"},
+			"second":      {"other": "%d秒", "short": "%d秒"},
+			"millisecond": {"other": "%d毫秒", "short": "%d毫秒"},
+			"microsecond": {"other": "%d微秒", "short": "%d微秒"},
+			"nanosecond":  {"other": "%d纳秒", "short": "%d纳秒"},
+		},
+		Relative: LocaleRelative{
+			Ago:           "%s前",
+			FromNow:       "%s后",
+			Before:        "%s前",
+			After:         "%s后",
+			JustNow:       "刚刚",
+			Separator:     strPtr(""),
+			ListSeparator: strPtr(""),
+			LastSeparator: strPtr(""),
+		},
+		Months:        []string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		ShortMonths:   []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		Weekdays:      []string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		ShortWeekdays: []string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		MinWeekdays:   []string{"日", "一", "二", "三", "四", "五", "六"},
+		Meridiem:      []string{"上午", "下午"},
+		Ordinal:       map[string]string{"other": "%d日"},
+		Calendar: LocaleCalendar{
+			SameDay:    "今天 {time}",
+			NextDay:    "明天 {time}",
+			LastDay:    "昨天 {time}",
+			NextWeek:   "下{weekday} {time}",
+			LastWeek:   "上{weekday} {time}",
+			ThisWeek:   "本{weekday} {time}",
+			SameElse:   "{date}",
+			TimeLayout: "15:04",
+			DateLayout: "2006/01/02",
+			Weekdays:   []string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		},
+		FirstDayOfWeek: "monday",
+		Formats: LocaleFormats{
+			FormattedDate: "2006年1月2日",
+			DayDateTime:   "2006年1月2日 Monday PM3:04",
+		},
+	},
+	{
+		Name: "zh-CN",
+	},
+	{
+		Name: "zh-TW",
+		Units: map[string]map[string]string{
+			"month":      {"other": "%d個月", "short": "%d個月"},
+			"week":       {"other": "%d週", "short": "%d週"},
+			"hour":       {"other": "%d小時", "short": "%d小時"},
+			"minute":     {"other": "%d分鐘", "short": "%d分"},
+			"nanosecond": {"other": "%d奈秒", "short": "%d奈秒"},
+		},
+		Relative: LocaleRelative{
+			Ago:     "%s前",
+			FromNow: "%s後",
+			Before:  "%s前",
+			After:   "%s後",
+			JustNow: "剛剛",
+		},
+		ShortWeekdays: []string{"週日", "週一", "週二", "週三", "週四", "週五", "週六"},
+		Calendar: LocaleCalendar{
+			Weekdays: []string{"週日", "週一", "週二", "週三", "週四", "週五", "週六"},
+		},
+		FirstDayOfWeek: "sunday",
+	},
+	{
+		Name: "ja",
+		Units: map[string]map[string]string{
+			"year":        {"other": "%d年", "short": "%d年"},
+			"month":       {"other": "%dヶ月", "short": "%dヶ月"},
+			"week":        {"other": "%d週間", "short": "%d週間"},
+			"day":         {"other": "%d日", "short": "%d日"},
+			"hour":        {"other": "%d時間", "short": "%d時間"},
+			"minute":      {"other": "%d分", "short": "%d分"},
+			"second":      {"other": "%d秒", "short": "%d秒"},
+			"millisecond": {"other": "%dミリ秒", "short": "%dミリ秒"},
+			"microsecond": {"other": "%dマイクロ秒", "short": "%dマイクロ秒"},
+			"nanosecond":  {"other": "%dナノ秒", "short": "%dナノ秒"},
+		},
+		Relative: LocaleRelative{
+			Ago:           "%s前",
+			FromNow:       "%s後",
+			Before:        "%s前",
+			After:         "%s後",
+			JustNow:       "たった今",
+			Separator:     strPtr(""),
+			ListSeparator: strPtr(""),
+			LastSeparator: strPtr(""),
+		},
+		Months:        []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		ShortMonths:   []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		Weekdays:      []string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		ShortWeekdays: []string{"日", "月", "火", "水", "木", "金", "土"},
+		MinWeekdays:   []string{"日", "月", "火", "水", "木", "金", "土"},
+		Meridiem:      []string{"午前", "午後"},
+		Ordinal:       map[string]string{"other": "%d日"},
+		Calendar: LocaleCalendar{
+			SameDay:    "今日 {time}",
+			NextDay:    "明日 {time}",
+			LastDay:    "昨日 {time}",
+			NextWeek:   "来週{weekday} {time}",
+			LastWeek:   "先週{weekday} {time}",
+			ThisWeek:   "{weekday} {time}",
+			SameElse:   "{date}",
+			TimeLayout: "15:04",
+			DateLayout: "2006/01/02",
+		},
+		FirstDayOfWeek: "sunday",
+		Formats: LocaleFormats{
+			FormattedDate: "2006年1月2日",
+			DayDateTime:   "2006年1月2日 Monday PM3:04",
+		},
+	},
+	{
+		Name: "ko",
+		Units: map[string]map[string]string{
+			"year":        {"other": "%d년", "short": "%d년"},
+			"month":       {"other": "%d개월", "short": "%d개월"},
+			"week":        {"other": "%d주", "short": "%d주"},
+			"day":         {"other": "%d일", "short": "%d일"},
+			"hour":        {"other": "%d시간", "short": "%d시간"},
+			"minute":      {"other": "%d분", "short": "%d분"},
+			"second":      {"other": "%d초", "short": "%d초"},
+			"millisecond": {"other": "%d밀리초", "short": "%d밀리초"},
+			"microsecond": {"other": "%d마이크로초", "short": "%d마이크로초"},
+			"nanosecond":  {"other": "%d나노초", "short": "%d나노초"},
+		},
+		Relative: LocaleRelative{
+			Ago:           "%s 전",
+			FromNow:       "%s 후",
+			Before:        "%s 전",
+			After:         "%s 후",
+			JustNow:       "방금",
+			Separator:     strPtr(" "),
+			ListSeparator: strPtr(" "),
+			LastSeparator: strPtr(" "),
+		},
+		Months:        []string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		ShortMonths:   []string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		Weekdays:      []string{"일요일", "월요일", "화요일", "수요일", "목요일", "금요일", "토요일"},
+		ShortWeekdays: []string{"일", "월", "화", "수", "목", "금", "토"},
+		MinWeekdays:   []string{"일", "월", "화", "수", "목", "금", "토"},
+		Meridiem:      []string{"오전", "오후"},
+		Ordinal:       map[string]string{"other": "%d일"},
+		Calendar: LocaleCalendar{
+			SameDay:    "오늘 {time}",
+			NextDay:    "내일 {time}",
+			LastDay:    "어제 {time}",
+			NextWeek:   "다음 주 {weekday} {time}",
+			LastWeek:   "지난주 {weekday} {time}",
+			ThisWeek:   "{weekday} {time}",
+			SameElse:   "{date}",
+			TimeLayout: "15:04",
+			DateLayout: "2006.01.02.",
+		},
+		FirstDayOfWeek: "sunday",
+		Formats: LocaleFormats{
+			FormattedDate: "2006년 1월 2일",
+			DayDateTime:   "2006년 1월 2일 Monday PM 3:04",
+		},
+	},
+	{
+		Name: "de",
+		Units: map[string]map[string]string{
+			"year":        {"one": "%d Jahr", "other": "%d Jahre", "short": "%d J."},
+			"month":       {"one": "%d Monat", "other": "%d Monate", "short": "%d Mon."},
+			"week":        {"one": "%d Woche", "other": "%d Wochen", "short": "%d Wo."},
+			"day":         {"one": "%d Tag", "other": "%d Tage", "short": "%d T."},
+			"hour":        {"one": "%d Stunde", "other": "%d Stunden", "short": "%d Std."},
+			"minute":      {"one": "%d Minute", "other": "%d Minuten", "short": "%d Min."},
+			"second":      {"one": "%d Sekunde", "other": "%d Sekunden", "short": "%d Sek."},
+			"millisecond": {"one": "%d Millisekunde", "other": "%d Millisekunden", "short": "%d ms"},
+			"microsecond": {"one": "%d Mikrosekunde", "other": "%d Mikrosekunden", "short": "%d µs"},
+			"nanosecond":  {"one": "%d Nanosekunde", "other": "%d Nanosekunden", "short": "%d ns"},
+		},
+		// "vor"、"in" 之后使用与格
+		RelativeUnits: map[string]map[string]string{
+			"year":  {"other": "%d Jahren"},
+			"month": {"other": "%d Monaten"},
+			"day":   {"other": "%d Tagen"},
+		},
+		Relative: LocaleRelative{
+			Ago:           "vor %s",
+			FromNow:       "in %s",
+			Before:        "%s davor",
+			After:         "%s danach",
+			JustNow:       "gerade eben",
+			Separator:     strPtr(" "),
+			ListSeparator: strPtr(", "),
+			LastSeparator: strPtr(" und "),
+		},
+		Months:        []string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		ShortMonths:   []string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		Weekdays:      []string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		ShortWeekdays: []string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		MinWeekdays:   []string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		Meridiem:      []string{"AM", "PM"},
+		Ordinal:       map[string]string{"other": "%d."},
+		Calendar: LocaleCalendar{
+			SameDay:    "heute um {time} Uhr",
+			NextDay:    "morgen um {time} Uhr",
+			LastDay:    "gestern um {time} Uhr",
+			NextWeek:   "{weekday} um {time} Uhr",
+			LastWeek:   "letzten {weekday} um {time} Uhr",
+			SameElse:   "{date}",
+			TimeLayout: "15:04",
+			DateLayout: "02.01.2006",
+		},
+		FirstDayOfWeek: "monday",
+		Formats: LocaleFormats{
+			FormattedDate: "2. Jan 2006",
+			DayDateTime:   "Mon, 2. Jan 2006 15:04",
+		},
+	},
+	{
+		Name: "fr",
+		Units: map[string]map[string]string{
+			"year":        {"one": "%d an", "other": "%d ans", "short": "%d a"},
+			"month":       {"one": "%d mois", "other": "%d mois", "short": "%d mois"},
+			"week":        {"one": "%d semaine", "other": "%d semaines", "short": "%d sem."},
+			"day":         {"one": "%d jour", "other": "%d jours", "short": "%d j"},
+			"hour":        {"one": "%d heure", "other": "%d heures", "short": "%d h"},
+			"minute":      {"one": "%d minute", "other": "%d minutes", "short": "%d min"},
+			"second":      {"one": "%d seconde", "other": "%d secondes", "short": "%d s"},
+			"millisecond": {"one": "%d milliseconde", "other": "%d millisecondes", "short": "%d ms"},
+			"microsecond": {"one": "%d microseconde", "other": "%d microsecondes", "short": "%d µs"},
+			"nanosecond":  {"one": "%d nanoseconde", "other": "%d nanosecondes", "short": "%d ns"},
+		},
+		Relative: LocaleRelative{
+			Ago:           "il y a %s",
+			FromNow:       "dans %s",
+			Before:        "%s avant",
+			After:         "%s après",
+			JustNow:       "à l'instant",
+			Separator:     strPtr(" "),
+			ListSeparator: strPtr(", "),
+			LastSeparator: strPtr(" et "),
+		},
+		Months:        []string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		ShortMonths:   []string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		Weekdays:      []string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		ShortWeekdays: []string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		MinWeekdays:   []string{"di", "lu", "ma", "me", "je", "ve", "sa"},
+		Meridiem:      []string{"AM", "PM"},
+		Ordinal:       map[string]string{"one": "%der", "other": "%de"},
+		Calendar: LocaleCalendar{
+			SameDay:    "Aujourd’hui à {time}",
+			NextDay:    "Demain à {time}",
+			LastDay:    "Hier à {time}",
+			NextWeek:   "{weekday} à {time}",
+			LastWeek:   "{weekday} dernier à {time}",
+			SameElse:   "{date}",
+			TimeLayout: "15:04",
+			DateLayout: "02/01/2006",
+		},
+		FirstDayOfWeek: "monday",
+		Formats: LocaleFormats{
+			FormattedDate: "2 Jan 2006",
+			DayDateTime:   "Mon 2 Jan 2006 15:04",
+		},
+	},
+	{
+		Name: "es",
+		Units: map[string]map[string]string{
+			"year":        {"one": "%d año", "other": "%d años", "short": "%d a"},
+			"month":       {"one": "%d mes", "other": "%d meses", "short": "%d mes"},
+			"week":        {"one": "%d semana", "other": "%d semanas", "short": "%d sem."},
+			"day":         {"one": "%d día", "other": "%d días", "short": "%d d"},
+			"hour":        {"one": "%d hora", "other": "%d horas", "short": "%d h"},
+			"minute":      {"one": "%d minuto", "other": "%d minutos", "short": "%d min"},
+			"second":      {"one": "%d segundo", "other": "%d segundos", "short": "%d s"},
+			"millisecond": {"one": "%d milisegundo", "other": "%d milisegundos", "short": "%d ms"},
+			"microsecond": {"one": "%d microsegundo", "other": "%d microsegundos", "short": "%d µs"},
+			"nanosecond":  {"one": "%d nanosegundo", "other": "%d nanosegundos", "short": "%d ns"},
+		},
+		Relative: LocaleRelative{
+			Ago:           "hace %s",
+			FromNow:       "dentro de %s",
+			Before:        "%s antes",
+			After:         "%s después",
+			JustNow:       "ahora mismo",
+			Separator:     strPtr(" "),
+			ListSeparator: strPtr(", "),
+			LastSeparator: strPtr(" y "),
+		},
+		Months:        []string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		ShortMonths:   []string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+		Weekdays:      []string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		ShortWeekdays: []string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		MinWeekdays:   []string{"do", "lu", "ma", "mi", "ju", "vi", "sá"},
+		Meridiem:      []string{"a. m.", "p. m."},
+		Ordinal:       map[string]string{"other": "%dº"},
+		Calendar: LocaleCalendar{
+			SameDay:    "hoy a las {time}",
+			NextDay:    "mañana a las {time}",
+			LastDay:    "ayer a las {time}",
+			NextWeek:   "{weekday} a las {time}",
+			LastWeek:   "el {weekday} pasado a las {time}",
+			SameElse:   "{date}",
+			TimeLayout: "15:04",
+			DateLayout: "02/01/2006",
+		},
+		FirstDayOfWeek: "monday",
+		Formats: LocaleFormats{
+			FormattedDate: "2 Jan 2006",
+			DayDateTime:   "Mon, 2 Jan 2006 15:04",
+		},
+	},
+}
+
+func init() {
+	for _, l := range builtinLocales {
+		if err := RegisterLocale(l); err != nil {
+			panic(err)
+		}
+	}
+}
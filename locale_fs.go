@@ -0,0 +1,50 @@
+//go:build go1.16
+// +build go1.16
+
+package carbon
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// LoadLocaleFS 从 fsys(如 embed.FS)中的 JSON 或 YAML 文件加载语言数据，数据中没有 name 时使用文件名
+func LoadLocaleFS(fsys fs.FS, name string) (*Locale, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	l, err := LoadLocale(data)
+	if err != nil {
+		return nil, err
+	}
+	if l.Name == "" {
+		base := path.Base(name)
+		l.Name = strings.TrimSuffix(base, path.Ext(base))
+	}
+	return l, nil
+}
+
+// RegisterLocalesFS 加载并注册 fsys 中 dir 目录下所有 .json、.yaml、.yml 文件的语言数据
+func RegisterLocalesFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		switch path.Ext(entry.Name()) {
+		case ".json", ".yaml", ".yml":
+		default:
+			continue
+		}
+		l, err := LoadLocaleFS(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		if err := RegisterLocale(l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
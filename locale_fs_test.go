@@ -0,0 +1,39 @@
+//go:build go1.16
+// +build go1.16
+
+package carbon
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterLocalesFS(t *testing.T) {
+	as := assert.New(t)
+	as.Nil(RegisterLocalesFS(os.DirFS("testdata"), "locales"))
+	defer unregisterLocale("ru")
+	defer unregisterLocale("en-GB")
+	as.Contains(Locales(), "ru")
+	as.Contains(Locales(), "en-GB")
+
+	fsys := fstest.MapFS{
+		"locales/it.yml":     {Data: []byte("relative: {ago: \"%s fa\", fromNow: \"tra %s\"}\n")},
+		"locales/README.md":  {Data: []byte("not a locale")},
+		"broken/months.json": {Data: []byte(`{"name": "xx", "months": ["gennaio"]}`)},
+	}
+	as.Nil(RegisterLocalesFS(fsys, "locales"))
+	defer unregisterLocale("it")
+	// 缺少的单位名称回退到英文
+	as.Equal("3 hours fa", CreateFromGo(time.Now().Add(-3*time.Hour-time.Second)).DiffForHumansWithOptions(&DiffOptions{Locale: "it"}))
+
+	l, err := LoadLocaleFS(fsys, "locales/it.yml")
+	as.Nil(err)
+	as.Equal("it", l.Name)
+	_, isLocaleErr := RegisterLocalesFS(fsys, "broken").(*LocaleError)
+	as.True(isLocaleErr)
+	as.NotNil(RegisterLocalesFS(fsys, "missing"))
+}
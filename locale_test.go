@@ -0,0 +1,160 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocale_Builtin(t *testing.T) {
+	as := assert.New(t)
+	for _, l := range builtinLocales {
+		err := l.Validate()
+		switch l.Name {
+		case "zh-CN", "zh-TW":
+			// 只定义了与 zh 不同的数据
+			as.NotNil(err, l.Name)
+			as.Empty(err.(*LocaleError).Invalid, l.Name)
+		default:
+			as.Nil(err, l.Name)
+		}
+	}
+
+	as.Equal([]string{"zh-TW", "zh", "en"}, LocaleFallbacks("zh-TW"))
+	as.Equal([]string{"zh-Hant-HK", "zh-Hant", "zh", "en"}, LocaleFallbacks("zh-Hant-HK"))
+	as.Equal([]string{"en-US", "en"}, LocaleFallbacks("en-US"))
+	as.Equal([]string{"en"}, LocaleFallbacks(""))
+
+	tw := ResolveLocale("zh-TW")
+	as.Equal("%d分鐘", tw.Units["minute"]["other"])
+	as.Equal("%d天", tw.Units["day"]["other"])
+	as.Equal("", *tw.Relative.Separator)
+	as.Equal("sunday", tw.FirstDayOfWeek)
+	as.Equal("一月", tw.Months[0])
+	as.Equal("en", ResolveLocale("xx").Name)
+	// 合并不会修改已注册的数据
+	as.Len(builtinLocales[3].Units, 5)
+
+	base := Create(2019, 4, 12, 8, 0, 0, time.UTC)
+	other := Create(2019, 4, 14, 11, 40, 5, time.UTC)
+	tests := []struct {
+		locale string
+		opts   DiffOptions
+		want   string
+	}{
+		{"zh-TW", DiffOptions{Parts: 3}, "2天3小時40分鐘前"},
+		{"zh-HK", DiffOptions{}, "2天前"},
+		{"ja", DiffOptions{Parts: 2}, "2日3時間前"},
+		{"ko", DiffOptions{Parts: 2}, "2일 3시간 전"},
+		{"de", DiffOptions{Parts: 3, Join: true}, "2 Tage, 3 Stunden und 40 Minuten davor"},
+		{"de", DiffOptions{Absolute: true}, "2 Tage"},
+		{"fr", DiffOptions{}, "2 jours avant"},
+		{"es", DiffOptions{Parts: 2, Short: true}, "2 d 3 h antes"},
+	}
+	for _, tt := range tests {
+		tt.opts.Locale = tt.locale
+		as.Equal(tt.want, base.DiffForHumansWithOptions(&tt.opts, other), tt.locale)
+	}
+	// 德语 "vor"、"in" 之后使用与格，其他情况使用主格
+	as.Equal("2 Tage danach", other.DiffForHumansWithOptions(&DiffOptions{Locale: "de"}, base))
+	as.Equal("vor 3 Tagen", CreateFromGo(time.Now().AddDate(0, 0, -3).Add(-time.Minute)).DiffForHumansWithOptions(&DiffOptions{Locale: "de"}))
+	as.Equal("in 2 Jahren", CreateFromGo(time.Now().AddDate(2, 0, 0).Add(time.Minute)).DiffForHumansWithOptions(&DiffOptions{Locale: "de"}))
+	as.Equal("vor 1 Tag", CreateFromGo(time.Now().AddDate(0, 0, -1).Add(-time.Minute)).DiffForHumansWithOptions(&DiffOptions{Locale: "de"}))
+	as.Equal("3 Tage 4 Stunden", FormatDuration(76*time.Hour, &DurationOptions{Locale: "de", Parts: 2}))
+	parsed, _, err := ParseDiffForHumans("vor 3 Tagen", base)
+	as.Nil(err)
+	as.Equal("2019-04-09", parsed.ToDateString())
+
+	// 法语的 0、1 使用单数
+	as.Equal("0 seconde", FormatDuration(0, &DurationOptions{Locale: "fr"}))
+	as.Equal("Hier à 08:00", base.Calendar(Create(2019, 4, 13, 9, 0, 0, time.UTC), &CalendarOptions{Locale: "fr"}))
+	as.Equal("gestern um 08:00 Uhr", base.Calendar(Create(2019, 4, 13, 9, 0, 0, time.UTC), &CalendarOptions{Locale: "de"}))
+	as.Equal("3rd", dateFormatsOf("en-US").Ordinal(3))
+	as.Equal("1er", dateFormatsOf("fr").Ordinal(1))
+	as.Equal("2e", dateFormatsOf("fr").Ordinal(2))
+}
+
+// unregisterLocale 删除测试中注册的语言，避免影响之后的测试
+func unregisterLocale(name string) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	delete(locales, name)
+	compiled = map[string]*compiledLocale{}
+}
+
+func TestLoadLocaleFile(t *testing.T) {
+	as := assert.New(t)
+	ru, err := LoadLocaleFile("testdata/locales/ru.yaml")
+	as.Nil(err)
+	as.Equal("ru", ru.Name)
+	err = ru.Validate()
+	as.NotNil(err)
+	as.Contains(err.(*LocaleError).Missing, "months")
+	as.Contains(err.(*LocaleError).Missing, "units.millisecond.one")
+	as.NotContains(err.(*LocaleError).Missing, "units.day.few")
+	as.Nil(RegisterLocale(ru))
+	defer unregisterLocale("ru")
+
+	base := Create(2019, 4, 12, 8, 0, 0, time.UTC)
+	for days, want := range map[int]string{1: "1 день", 2: "2 дня", 5: "5 дней", 11: "11 дней", 21: "21 день", 22: "22 дня", 112: "112 дней"} {
+		as.Equal(want, FormatDuration(time.Duration(days)*24*time.Hour, &DurationOptions{Locale: "ru"}))
+	}
+	as.Equal("2 дня до", base.DiffForHumansWithOptions(&DiffOptions{Locale: "ru"}, Create(2019, 4, 14, 8, 0, 0, time.UTC)))
+	as.Equal("через 3 часа", Now().AddHours(3).AddSeconds(1).DiffForHumansWithOptions(&DiffOptions{Locale: "ru"}))
+	// 缺少的数据回退到英文
+	as.Equal("12 April 2019", base.FormatLocale("2 January 2006", "ru"))
+	c, unit, err := ParseDiffForHumans("5 дней назад", base)
+	as.Nil(err)
+	as.Equal(Day, unit)
+	as.Equal("2019-04-07", c.ToDateString())
+
+	gb, err := LoadLocaleFile("testdata/locales/en-GB.json")
+	as.Nil(err)
+	as.Nil(RegisterLocale(gb))
+	defer unregisterLocale("en-GB")
+	as.Equal("12 Apr 2019", base.ToFormattedDateString("en-GB"))
+	as.Equal("Fri, Apr 12, 2019 8:00 AM", base.ToDayDateTimeString("en-GB"))
+	as.Equal("12/04/2019", base.Calendar(Create(2019, 5, 1, 0, 0, 0, time.UTC), &CalendarOptions{Locale: "en-GB"}))
+	as.Equal(time.Monday, calendarFormatsOf("en-GB").WeekStart)
+
+	_, err = LoadLocaleFile("testdata/locales/none.yaml")
+	as.NotNil(err)
+	_, err = LoadLocale([]byte("unknown: 1"))
+	as.NotNil(err)
+	_, err = LoadLocale([]byte(`{"months": 1}`))
+	as.NotNil(err)
+	_, err = LoadLocale([]byte(`{"monts": ["Jan"]}`))
+	as.NotNil(err)
+	_, err = LoadLocale([]byte(`{"name": "xx"} {}`))
+	as.NotNil(err)
+	l, err := LoadLocale([]byte(` {"name": "xx", "parent": "en"}`))
+	as.Nil(err)
+	as.Equal("en", l.Parent)
+}
+
+func TestRegisterLocale_Invalid(t *testing.T) {
+	as := assert.New(t)
+	err := RegisterLocale(&Locale{})
+	as.Equal(&LocaleError{Missing: []string{"name"}}, err)
+
+	err = RegisterLocale(&Locale{
+		Name:           "xx",
+		Units:          map[string]map[string]string{"day": {"one": "day"}, "days": {"other": "%d"}},
+		Relative:       LocaleRelative{Ago: "ago"},
+		Months:         []string{"Jan"},
+		FirstDayOfWeek: "someday",
+	})
+	e, ok := err.(*LocaleError)
+	as.True(ok)
+	as.Equal([]string{
+		`firstDayOfWeek: unknown weekday "someday"`,
+		"months: want 12 items, got 1",
+		`relative.ago: "ago" has no %s`,
+		`units.day.one: "day" has no %d`,
+		"units.days: unknown unit",
+	}, e.Invalid)
+	as.Equal(`locale "xx": invalid firstDayOfWeek: unknown weekday "someday"; months: want 12 items, got 1; `+
+		`relative.ago: "ago" has no %s; units.day.one: "day" has no %d; units.days: unknown unit`, e.Error())
+	as.NotContains(Locales(), "xx")
+}
@@ -0,0 +1,212 @@
+package carbon
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// LunarMinYear 支持的最小农历年份
+	LunarMinYear = 1900
+	// LunarMaxYear 支持的最大农历年份
+	LunarMaxYear = 2100
+)
+
+// lunarInfo 1900-2100 年的农历数据
+// 0-3 位表示闰月月份，为 0 则没有闰月；4-15 位依次表示 12 月到 1 月是否为大月(30天)；
+// 16 位表示闰月是否为大月
+var lunarInfo = [...]int{
+	0x04bd8, 0x04ae0, 0x0a570, 0x054d5, 0x0d260, 0x0d950, 0x16554, 0x056a0, 0x09ad0, 0x055d2, // 1900-1909
+	0x04ae0, 0x0a5b6, 0x0a4d0, 0x0d250, 0x1d255, 0x0b540, 0x0d6a0, 0x0ada2, 0x095b0, 0x14977, // 1910-1919
+	0x04970, 0x0a4b0, 0x0b4b5, 0x06a50, 0x06d40, 0x1ab54, 0x02b60, 0x09570, 0x052f2, 0x04970, // 1920-1929
+	0x06566, 0x0d4a0, 0x0ea50, 0x16a95, 0x05ad0, 0x02b60, 0x186e3, 0x092e0, 0x1c8d7, 0x0c950, // 1930-1939
+	0x0d4a0, 0x1d8a6, 0x0b550, 0x056a0, 0x1a5b4, 0x025d0, 0x092d0, 0x0d2b2, 0x0a950, 0x0b557, // 1940-1949
+	0x06ca0, 0x0b550, 0x15355, 0x04da0, 0x0a5b0, 0x14573, 0x052b0, 0x0a9a8, 0x0e950, 0x06aa0, // 1950-1959
+	0x0aea6, 0x0ab50, 0x04b60, 0x0aae4, 0x0a570, 0x05260, 0x0f263, 0x0d950, 0x05b57, 0x056a0, // 1960-1969
+	0x096d0, 0x04dd5, 0x04ad0, 0x0a4d0, 0x0d4d4, 0x0d250, 0x0d558, 0x0b540, 0x0b6a0, 0x195a6, // 1970-1979
+	0x095b0, 0x049b0, 0x0a974, 0x0a4b0, 0x0b27a, 0x06a50, 0x06d40, 0x0af46, 0x0ab60, 0x09570, // 1980-1989
+	0x04af5, 0x04970, 0x064b0, 0x074a3, 0x0ea50, 0x06b58, 0x05ac0, 0x0ab60, 0x096d5, 0x092e0, // 1990-1999
+	0x0c960, 0x0d954, 0x0d4a0, 0x0da50, 0x07552, 0x056a0, 0x0abb7, 0x025d0, 0x092d0, 0x0cab5, // 2000-2009
+	0x0a950, 0x0b4a0, 0x0baa4, 0x0ad50, 0x055d9, 0x04ba0, 0x0a5b0, 0x15176, 0x052b0, 0x0a930, // 2010-2019
+	0x07954, 0x06aa0, 0x0ad50, 0x05b52, 0x04b60, 0x0a6e6, 0x0a4e0, 0x0d260, 0x0ea65, 0x0d530, // 2020-2029
+	0x05aa0, 0x076a3, 0x096d0, 0x04afb, 0x04ad0, 0x0a4d0, 0x1d0b6, 0x0d250, 0x0d520, 0x0dd45, // 2030-2039
+	0x0b5a0, 0x056d0, 0x055b2, 0x049b0, 0x0a577, 0x0a4b0, 0x0aa50, 0x1b255, 0x06d20, 0x0ada0, // 2040-2049
+	0x14b63, 0x09370, 0x049f8, 0x04970, 0x064b0, 0x168a6, 0x0ea50, 0x06b20, 0x1a6c4, 0x0aae0, // 2050-2059
+	0x092e0, 0x0d2e3, 0x0c960, 0x0d557, 0x0d4a0, 0x0da50, 0x05d55, 0x056a0, 0x0a6d0, 0x055d4, // 2060-2069
+	0x052d0, 0x0a9b8, 0x0a950, 0x0b4a0, 0x0b6a6, 0x0ad50, 0x055a0, 0x0aba4, 0x0a5b0, 0x052b0, // 2070-2079
+	0x0b273, 0x06930, 0x07337, 0x06aa0, 0x0ad50, 0x14b55, 0x04b60, 0x0a570, 0x054e4, 0x0d160, // 2080-2089
+	0x0e968, 0x0d520, 0x0daa0, 0x16aa6, 0x056d0, 0x04ae0, 0x0a9d4, 0x0a2d0, 0x0d150, 0x0f252, // 2090-2099
+	0x0d520, // 2100
+}
+
+var (
+	// lunarBase 农历1900年正月初一对应的公历日期
+	lunarBase = time.Date(1900, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	lunarNumbers   = []string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九", "十"}
+	lunarMonths    = []string{"", "正", "二", "三", "四", "五", "六", "七", "八", "九", "十", "冬", "腊"}
+	lunarDayPrefix = []string{"初", "十", "廿", "三"}
+)
+
+// Lunar 农历日期
+type Lunar struct {
+	Year, Month, Day int
+	// IsLeap 是否是闰月
+	IsLeap bool
+}
+
+// lunarLeapMonth 返回农历 year 年闰哪个月，没有闰月返回 0
+func lunarLeapMonth(year int) int {
+	return lunarInfo[year-LunarMinYear] & 0xf
+}
+
+// lunarLeapDays 返回农历 year 年闰月的天数，没有闰月返回 0
+func lunarLeapDays(year int) int {
+	if lunarLeapMonth(year) == 0 {
+		return 0
+	}
+	if lunarInfo[year-LunarMinYear]&0x10000 != 0 {
+		return 30
+	}
+	return 29
+}
+
+// lunarMonthDays 返回农历 year 年 month 月(非闰月)的天数
+func lunarMonthDays(year, month int) int {
+	if lunarInfo[year-LunarMinYear]&(0x10000>>uint(month)) != 0 {
+		return 30
+	}
+	return 29
+}
+
+// lunarYearDays 返回农历 year 年的总天数
+func lunarYearDays(year int) int {
+	days := 0
+	for m := 1; m <= 12; m++ {
+		days += lunarMonthDays(year, m)
+	}
+	return days + lunarLeapDays(year)
+}
+
+// Lunar 返回农历日期，超出 1900-2100 年范围返回 nil
+func (c *Carbon) Lunar() *Lunar {
+	date := time.Date(c.time.Year(), c.time.Month(), c.time.Day(), 0, 0, 0, 0, time.UTC)
+	offset := int(date.Sub(lunarBase).Hours() / 24)
+	if offset < 0 {
+		return nil
+	}
+	year := LunarMinYear
+	for ; year <= LunarMaxYear; year++ {
+		days := lunarYearDays(year)
+		if offset < days {
+			break
+		}
+		offset -= days
+	}
+	if year > LunarMaxYear {
+		return nil
+	}
+
+	leap := lunarLeapMonth(year)
+	for month := 1; month <= 12; month++ {
+		days := lunarMonthDays(year, month)
+		if offset < days {
+			return &Lunar{Year: year, Month: month, Day: offset + 1}
+		}
+		offset -= days
+		if month == leap {
+			days = lunarLeapDays(year)
+			if offset < days {
+				return &Lunar{Year: year, Month: month, Day: offset + 1, IsLeap: true}
+			}
+			offset -= days
+		}
+	}
+	return nil
+}
+
+// CreateFromLunar 从农历日期中创建 Carbon，时间部分使用当前时间
+func CreateFromLunar(year, month, day int, isLeap bool, tz *time.Location) (*Carbon, error) {
+	if year < LunarMinYear || year > LunarMaxYear {
+		return &Carbon{}, ErrLunarOutOfRange
+	}
+	if month < 1 || month > 12 || (isLeap && lunarLeapMonth(year) != month) {
+		return &Carbon{}, ErrLunarDate
+	}
+	days := lunarMonthDays(year, month)
+	if isLeap {
+		days = lunarLeapDays(year)
+	}
+	if day < 1 || day > days {
+		return &Carbon{}, ErrLunarDate
+	}
+
+	offset := 0
+	for y := LunarMinYear; y < year; y++ {
+		offset += lunarYearDays(y)
+	}
+	leap := lunarLeapMonth(year)
+	for m := 1; m < month; m++ {
+		offset += lunarMonthDays(year, m)
+		if m == leap {
+			offset += lunarLeapDays(year)
+		}
+	}
+	if isLeap {
+		offset += lunarMonthDays(year, month)
+	}
+	offset += day - 1
+
+	date := lunarBase.AddDate(0, 0, offset)
+	return CreateFromDate(date.Year(), int(date.Month()), date.Day(), tz), nil
+}
+
+// LeapMonth 返回当年闰哪个月，没有闰月返回 0
+func (l *Lunar) LeapMonth() int {
+	return lunarLeapMonth(l.Year)
+}
+
+// MonthDays 返回当前农历月的天数
+func (l *Lunar) MonthDays() int {
+	if l.IsLeap {
+		return lunarLeapDays(l.Year)
+	}
+	return lunarMonthDays(l.Year, l.Month)
+}
+
+// YearString 返回中文的农历年，如 "二〇一九"
+func (l *Lunar) YearString() string {
+	var b strings.Builder
+	for _, r := range strconv.Itoa(l.Year) {
+		b.WriteString(lunarNumbers[r-'0'])
+	}
+	return b.String()
+}
+
+// MonthString 返回中文的农历月，如 "三月"、"闰四月"
+func (l *Lunar) MonthString() string {
+	s := lunarMonths[l.Month] + "月"
+	if l.IsLeap {
+		return "闰" + s
+	}
+	return s
+}
+
+// DayString 返回中文的农历日，如 "初八"、"廿一"
+func (l *Lunar) DayString() string {
+	switch l.Day {
+	case 10:
+		return "初十"
+	case 20:
+		return "二十"
+	case 30:
+		return "三十"
+	}
+	return lunarDayPrefix[l.Day/10] + lunarNumbers[l.Day%10]
+}
+
+// String 返回中文的农历日期，如 "二〇一九年三月初八"
+func (l *Lunar) String() string {
+	return l.YearString() + "年" + l.MonthString() + l.DayString()
+}
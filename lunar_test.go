@@ -0,0 +1,87 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarbon_Lunar(t *testing.T) {
+	as := assert.New(t)
+	tests := []struct {
+		year, month, day int
+		want             Lunar
+		str              string
+	}{
+		{1900, 1, 31, Lunar{1900, 1, 1, false}, "一九〇〇年正月初一"},
+		{1985, 2, 20, Lunar{1985, 1, 1, false}, "一九八五年正月初一"},
+		{2000, 2, 4, Lunar{1999, 12, 29, false}, "一九九九年腊月廿九"},
+		{2019, 2, 5, Lunar{2019, 1, 1, false}, "二〇一九年正月初一"},
+		{2019, 4, 12, Lunar{2019, 3, 8, false}, "二〇一九年三月初八"},
+		{2020, 5, 23, Lunar{2020, 4, 1, true}, "二〇二〇年闰四月初一"},
+		{2023, 3, 22, Lunar{2023, 2, 1, true}, "二〇二三年闰二月初一"},
+		{2033, 12, 22, Lunar{2033, 11, 1, true}, "二〇三三年闰冬月初一"},
+		{2057, 9, 28, Lunar{2057, 8, 30, false}, "二〇五七年八月三十"},
+		{2100, 12, 31, Lunar{2100, 12, 1, false}, "二一〇〇年腊月初一"},
+	}
+	for _, tt := range tests {
+		l := Create(tt.year, tt.month, tt.day, 12, 0, 0, time.Local).Lunar()
+		if as.NotNil(l) {
+			as.Equal(tt.want, *l)
+			as.Equal(tt.str, l.String())
+		}
+	}
+
+	as.Nil(Create(1900, 1, 30, 0, 0, 0, time.UTC).Lunar())
+	as.Nil(Create(2101, 2, 1, 0, 0, 0, time.UTC).Lunar())
+}
+
+func TestLunar_DayString(t *testing.T) {
+	as := assert.New(t)
+	want := map[int]string{1: "初一", 10: "初十", 11: "十一", 20: "二十", 21: "廿一", 29: "廿九", 30: "三十"}
+	for day, s := range want {
+		as.Equal(s, (&Lunar{Year: 2019, Month: 1, Day: day}).DayString())
+	}
+}
+
+func TestCreateFromLunar(t *testing.T) {
+	as := assert.New(t)
+	tests := []struct {
+		year, month, day int
+		isLeap           bool
+		want             string
+	}{
+		{1900, 1, 1, false, "1900-01-31"},
+		{2019, 3, 8, false, "2019-04-12"},
+		{2020, 4, 1, false, "2020-04-23"},
+		{2020, 4, 1, true, "2020-05-23"},
+		{2033, 11, 1, true, "2033-12-22"},
+		{2100, 12, 1, false, "2100-12-31"},
+	}
+	for _, tt := range tests {
+		c, err := CreateFromLunar(tt.year, tt.month, tt.day, tt.isLeap, time.Local)
+		as.NoError(err)
+		as.Equal(tt.want, c.ToDateString())
+	}
+
+	_, err := CreateFromLunar(1899, 1, 1, false, time.Local)
+	as.Equal(ErrLunarOutOfRange, err)
+	_, err = CreateFromLunar(2019, 4, 1, true, time.Local)
+	as.Equal(ErrLunarDate, err)
+	_, err = CreateFromLunar(2019, 2, 30, false, time.Local)
+	as.Equal(ErrLunarDate, err)
+}
+
+func TestLunar_RoundTrip(t *testing.T) {
+	as := assert.New(t)
+	c := Create(1900, 1, 31, 12, 0, 0, time.UTC)
+	for c.Year < 2101 {
+		l := c.Lunar()
+		got, err := CreateFromLunar(l.Year, l.Month, l.Day, l.IsLeap, time.UTC)
+		if !as.NoError(err) || !as.Equal(c.ToDateString(), got.ToDateString()) {
+			return
+		}
+		c = CreateFromGo(c.time.AddDate(0, 0, 1))
+	}
+}
@@ -0,0 +1,232 @@
+package carbon
+
+import (
+	"strings"
+	"time"
+)
+
+// 常用的时间格式
+const (
+	// AtomLayout Atom 格式，如 "2019-04-12T15:04:05+08:00"
+	AtomLayout = "2006-01-02T15:04:05-07:00"
+	// CookieLayout Cookie 格式，如 "Friday, 12-Apr-2019 15:04:05 CST"
+	CookieLayout = "Monday, 02-Jan-2006 15:04:05 MST"
+	// Iso8601Layout ISO 8601 格式，如 "2019-04-12T15:04:05+08:00"
+	Iso8601Layout = "2006-01-02T15:04:05-07:00"
+	// Rfc822Layout RFC 822 格式，如 "Fri, 12 Apr 19 15:04:05 +0800"
+	Rfc822Layout = "Mon, 02 Jan 06 15:04:05 -0700"
+	// Rfc850Layout RFC 850 格式，如 "Friday, 12-Apr-19 15:04:05 CST"
+	Rfc850Layout = "Monday, 02-Jan-06 15:04:05 MST"
+	// Rfc1036Layout RFC 1036 格式，如 "Fri, 12 Apr 19 15:04:05 +0800"
+	Rfc1036Layout = "Mon, 02 Jan 06 15:04:05 -0700"
+	// Rfc1123Layout RFC 1123 格式，如 "Fri, 12 Apr 2019 15:04:05 +0800"
+	Rfc1123Layout = "Mon, 02 Jan 2006 15:04:05 -0700"
+	// Rfc2822Layout RFC 2822 格式，如 "Fri, 12 Apr 2019 15:04:05 +0800"
+	Rfc2822Layout = "Mon, 02 Jan 2006 15:04:05 -0700"
+	// Rfc3339Layout RFC 3339 格式，如 "2019-04-12T15:04:05+08:00"，UTC 时为 "2019-04-12T07:04:05Z"
+	Rfc3339Layout = time.RFC3339
+	// Rfc7231Layout RFC 7231 格式，即 HTTP 使用的 GMT 时间，如 "Fri, 12 Apr 2019 07:04:05 GMT"
+	Rfc7231Layout = "Mon, 02 Jan 2006 15:04:05 GMT"
+	// RssLayout RSS 格式，如 "Fri, 12 Apr 2019 15:04:05 +0800"
+	RssLayout = "Mon, 02 Jan 2006 15:04:05 -0700"
+	// W3cLayout W3C 格式，如 "2019-04-12T15:04:05+08:00"
+	W3cLayout = "2006-01-02T15:04:05-07:00"
+	// DayDateTimeLayout 带星期的日期时间，如 "Fri, Apr 12, 2019 3:04 PM"
+	DayDateTimeLayout = "Mon, Jan 2, 2006 3:04 PM"
+	// DateTimeMilliLayout 精确到毫秒的日期时间，如 "2019-04-12 15:04:05.999"
+	DateTimeMilliLayout = "2006-01-02 15:04:05.000"
+)
+
+// ToAtomString 返回 Atom 格式的字符串，如 "2019-04-12T15:04:05+08:00"
+func (c *Carbon) ToAtomString() string {
+	return c.Format(AtomLayout)
+}
+
+// ToCookieString 返回 Cookie 格式的字符串，如 "Friday, 12-Apr-2019 15:04:05 CST"
+func (c *Carbon) ToCookieString() string {
+	return c.Format(CookieLayout)
+}
+
+// ToIso8601String 返回 ISO 8601 格式的字符串，如 "2019-04-12T15:04:05+08:00"，
+// precision 为秒的小数位数(0 到 9)，如 3 时返回 "2019-04-12T15:04:05.999+08:00"
+func (c *Carbon) ToIso8601String(precision ...int) string {
+	n := 0
+	if len(precision) > 0 {
+		n = precision[0]
+	}
+	return c.Format(fractionLayout(Iso8601Layout, n))
+}
+
+// ToRfc822String 返回 RFC 822 格式的字符串，如 "Fri, 12 Apr 19 15:04:05 +0800"
+func (c *Carbon) ToRfc822String() string {
+	return c.Format(Rfc822Layout)
+}
+
+// ToRfc850String 返回 RFC 850 格式的字符串，如 "Friday, 12-Apr-19 15:04:05 CST"
+func (c *Carbon) ToRfc850String() string {
+	return c.Format(Rfc850Layout)
+}
+
+// ToRfc1036String 返回 RFC 1036 格式的字符串，如 "Fri, 12 Apr 19 15:04:05 +0800"
+func (c *Carbon) ToRfc1036String() string {
+	return c.Format(Rfc1036Layout)
+}
+
+// ToRfc1123String 返回 RFC 1123 格式的字符串，如 "Fri, 12 Apr 2019 15:04:05 +0800"
+func (c *Carbon) ToRfc1123String() string {
+	return c.Format(Rfc1123Layout)
+}
+
+// ToRfc2822String 返回 RFC 2822 格式的字符串，如 "Fri, 12 Apr 2019 15:04:05 +0800"
+func (c *Carbon) ToRfc2822String() string {
+	return c.Format(Rfc2822Layout)
+}
+
+// ToRfc3339String 返回 RFC 3339 格式的字符串，如 "2019-04-12T15:04:05+08:00"
+func (c *Carbon) ToRfc3339String() string {
+	return c.Format(Rfc3339Layout)
+}
+
+// ToRfc7231String 返回 HTTP 使用的 GMT 时间，如 "Fri, 12 Apr 2019 07:04:05 GMT"
+func (c *Carbon) ToRfc7231String() string {
+	return c.time.UTC().Format(Rfc7231Layout)
+}
+
+// ToRssString 返回 RSS 格式的字符串，如 "Fri, 12 Apr 2019 15:04:05 +0800"
+func (c *Carbon) ToRssString() string {
+	return c.Format(RssLayout)
+}
+
+// ToW3cString 返回 W3C 格式的字符串，如 "2019-04-12T15:04:05+08:00"
+func (c *Carbon) ToW3cString() string {
+	return c.Format(W3cLayout)
+}
+
+// ToDateTimeMilliString 返回精确到毫秒的日期时间，如 "2019-04-12 15:04:05.999"
+func (c *Carbon) ToDateTimeMilliString() string {
+	return c.Format(DateTimeMilliLayout)
+}
+
+// fractionLayout 在布局的秒之后加上 n 位小数
+func fractionLayout(layout string, n int) string {
+	if n <= 0 {
+		return layout
+	}
+	if n > 9 {
+		n = 9
+	}
+	return strings.Replace(layout, "05", "05."+strings.Repeat("0", n), 1)
+}
+
+// fractionDigits 返回字符串中秒的小数位数，如 "15:04:05.999+08:00" 返回 3
+func fractionDigits(value string) int {
+	i := strings.IndexByte(value, '.')
+	if i < 0 {
+		return 0
+	}
+	j := i + 1
+	for j < len(value) && value[j] >= '0' && value[j] <= '9' {
+		j++
+	}
+	return j - i - 1
+}
+
+// parseStrict 严格按照布局解析时间字符串。time.Parse 不校验星期，且会接受布局之外的秒的小数部分，
+// 因此解析后重新格式化，需与原字符串一致，"+00:00" 可以写作 "Z"
+func parseStrict(layout, value string, tz *time.Location) (*Carbon, error) {
+	t, err := time.ParseInLocation(layout, value, tz)
+	if err != nil {
+		return &Carbon{}, ErrTimeParse
+	}
+	if s := t.Format(layout); s != value && !(strings.HasSuffix(s, "Z") && strings.TrimSuffix(s, "Z")+"+00:00" == value) {
+		return &Carbon{}, ErrTimeParse
+	}
+	return CreateFromGo(t), nil
+}
+
+// ParseAtom 严格解析 Atom 格式的字符串，如 "2019-04-12T15:04:05+08:00"
+func ParseAtom(value string, tz *time.Location) (*Carbon, error) {
+	return parseStrict(AtomLayout, value, tz)
+}
+
+// ParseCookie 严格解析 Cookie 格式的字符串，如 "Friday, 12-Apr-2019 15:04:05 CST"，时区缩写按 tz 识别
+func ParseCookie(value string, tz *time.Location) (*Carbon, error) {
+	return parseStrict(CookieLayout, value, tz)
+}
+
+// ParseIso8601 严格解析 ISO 8601 格式的字符串，秒的小数部分可选，时区可以是 "Z"，
+// 如 "2019-04-12T15:04:05+08:00"、"2019-04-12T07:04:05.999Z"
+func ParseIso8601(value string, tz *time.Location) (*Carbon, error) {
+	n := fractionDigits(value)
+	if n > 9 {
+		return &Carbon{}, ErrTimeParse
+	}
+	layout := fractionLayout(Iso8601Layout, n)
+	if strings.HasSuffix(value, "Z") {
+		layout = strings.Replace(layout, "-07:00", "Z07:00", 1)
+	}
+	return parseStrict(layout, value, tz)
+}
+
+// ParseRfc822 严格解析 RFC 822 格式的字符串，如 "Fri, 12 Apr 19 15:04:05 +0800"
+func ParseRfc822(value string, tz *time.Location) (*Carbon, error) {
+	return parseStrict(Rfc822Layout, value, tz)
+}
+
+// ParseRfc850 严格解析 RFC 850 格式的字符串，如 "Friday, 12-Apr-19 15:04:05 CST"，时区缩写按 tz 识别
+func ParseRfc850(value string, tz *time.Location) (*Carbon, error) {
+	return parseStrict(Rfc850Layout, value, tz)
+}
+
+// ParseRfc1036 严格解析 RFC 1036 格式的字符串，如 "Fri, 12 Apr 19 15:04:05 +0800"
+func ParseRfc1036(value string, tz *time.Location) (*Carbon, error) {
+	return parseStrict(Rfc1036Layout, value, tz)
+}
+
+// ParseRfc1123 严格解析 RFC 1123 格式的字符串，如 "Fri, 12 Apr 2019 15:04:05 +0800"
+func ParseRfc1123(value string, tz *time.Location) (*Carbon, error) {
+	return parseStrict(Rfc1123Layout, value, tz)
+}
+
+// ParseRfc2822 严格解析 RFC 2822 格式的字符串，如 "Fri, 12 Apr 2019 15:04:05 +0800"
+func ParseRfc2822(value string, tz *time.Location) (*Carbon, error) {
+	return parseStrict(Rfc2822Layout, value, tz)
+}
+
+// ParseRfc3339 严格解析 RFC 3339 格式的字符串，秒的小数部分可选，如 "2019-04-12T15:04:05+08:00"、"2019-04-12T07:04:05.5Z"
+func ParseRfc3339(value string, tz *time.Location) (*Carbon, error) {
+	n := fractionDigits(value)
+	if n > 9 {
+		return &Carbon{}, ErrTimeParse
+	}
+	return parseStrict(fractionLayout(Rfc3339Layout, n), value, tz)
+}
+
+// ParseRfc7231 严格解析 HTTP 使用的 GMT 时间，如 "Fri, 12 Apr 2019 07:04:05 GMT"，返回 tz 时区的时间
+func ParseRfc7231(value string, tz *time.Location) (*Carbon, error) {
+	c, err := parseStrict(Rfc7231Layout, value, time.UTC)
+	if err != nil {
+		return c, err
+	}
+	return CreateFromGo(c.time.In(tz)), nil
+}
+
+// ParseRss 严格解析 RSS 格式的字符串，如 "Fri, 12 Apr 2019 15:04:05 +0800"
+func ParseRss(value string, tz *time.Location) (*Carbon, error) {
+	return parseStrict(RssLayout, value, tz)
+}
+
+// ParseW3c 严格解析 W3C 格式的字符串，如 "2019-04-12T15:04:05+08:00"
+func ParseW3c(value string, tz *time.Location) (*Carbon, error) {
+	return parseStrict(W3cLayout, value, tz)
+}
+
+// ParseDayDateTime 严格解析带星期的日期时间，如 "Fri, Apr 12, 2019 3:04 PM"
+func ParseDayDateTime(value string, tz *time.Location) (*Carbon, error) {
+	return parseStrict(DayDateTimeLayout, value, tz)
+}
+
+// ParseDateTimeMilli 严格解析精确到毫秒的日期时间，如 "2019-04-12 15:04:05.999"
+func ParseDateTimeMilli(value string, tz *time.Location) (*Carbon, error) {
+	return parseStrict(DateTimeMilliLayout, value, tz)
+}
@@ -0,0 +1,102 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarbon_NamedFormats(t *testing.T) {
+	as := assert.New(t)
+	tz := time.FixedZone("CST", 8*3600)
+	c := CreateFromGo(time.Date(2019, 4, 12, 15, 4, 5, 123456789, tz))
+	tests := []struct {
+		name  string
+		value string
+		parse func(string, *time.Location) (*Carbon, error)
+	}{
+		{"Atom", c.ToAtomString(), ParseAtom},
+		{"Cookie", c.ToCookieString(), ParseCookie},
+		{"Iso8601", c.ToIso8601String(), ParseIso8601},
+		{"Rfc822", c.ToRfc822String(), ParseRfc822},
+		{"Rfc850", c.ToRfc850String(), ParseRfc850},
+		{"Rfc1036", c.ToRfc1036String(), ParseRfc1036},
+		{"Rfc1123", c.ToRfc1123String(), ParseRfc1123},
+		{"Rfc2822", c.ToRfc2822String(), ParseRfc2822},
+		{"Rfc3339", c.ToRfc3339String(), ParseRfc3339},
+		{"Rfc7231", c.ToRfc7231String(), ParseRfc7231},
+		{"Rss", c.ToRssString(), ParseRss},
+		{"W3c", c.ToW3cString(), ParseW3c},
+	}
+	want := []string{
+		"2019-04-12T15:04:05+08:00",
+		"Friday, 12-Apr-2019 15:04:05 CST",
+		"2019-04-12T15:04:05+08:00",
+		"Fri, 12 Apr 19 15:04:05 +0800",
+		"Friday, 12-Apr-19 15:04:05 CST",
+		"Fri, 12 Apr 19 15:04:05 +0800",
+		"Fri, 12 Apr 2019 15:04:05 +0800",
+		"Fri, 12 Apr 2019 15:04:05 +0800",
+		"2019-04-12T15:04:05+08:00",
+		"Fri, 12 Apr 2019 07:04:05 GMT",
+		"Fri, 12 Apr 2019 15:04:05 +0800",
+		"2019-04-12T15:04:05+08:00",
+	}
+	for i, test := range tests {
+		as.Equal(want[i], test.value, test.name)
+		parsed, err := test.parse(test.value, tz)
+		as.Nil(err, test.name)
+		as.True(parsed.time.Equal(c.time.Truncate(time.Second)), test.name)
+	}
+
+	as.Equal("2019-04-12T15:04:05.123+08:00", c.ToIso8601String(3))
+	as.Equal("2019-04-12T15:04:05.123456789+08:00", c.ToIso8601String(9))
+	as.Equal("2019-04-12 15:04:05.123", c.ToDateTimeMilliString())
+	as.Equal("Fri, Apr 12, 2019 3:04 PM", c.ToDayDateTimeString())
+
+	parsed, err := ParseDateTimeMilli("2019-04-12 15:04:05.123", tz)
+	as.Nil(err)
+	as.Equal(123*time.Millisecond, time.Duration(parsed.time.Nanosecond()))
+	parsed, err = ParseDayDateTime("Fri, Apr 12, 2019 3:04 PM", tz)
+	as.Nil(err)
+	as.Equal("2019-04-12 15:04:00", parsed.ToDateTimeString())
+	parsed, err = ParseIso8601("2019-04-12T07:04:05.5Z", tz)
+	as.Nil(err)
+	as.True(parsed.time.Equal(time.Date(2019, 4, 12, 15, 4, 5, 5e8, tz)))
+	parsed, err = ParseRfc3339("2019-04-12T07:04:05+00:00", tz)
+	as.Nil(err)
+	as.True(parsed.time.Equal(time.Date(2019, 4, 12, 15, 4, 5, 0, tz)))
+	parsed, err = ParseRfc7231("Fri, 12 Apr 2019 07:04:05 GMT", tz)
+	as.Nil(err)
+	as.Equal("2019-04-12 15:04:05", parsed.ToDateTimeString())
+}
+
+func TestParseNamedFormats_Strict(t *testing.T) {
+	as := assert.New(t)
+	tests := []struct {
+		value string
+		parse func(string, *time.Location) (*Carbon, error)
+	}{
+		// 星期与日期不符
+		{"Thu, 12 Apr 2019 15:04:05 +0800", ParseRfc1123},
+		{"Thursday, 12-Apr-19 15:04:05 CST", ParseRfc850},
+		// 多余的小数部分
+		{"2019-04-12T15:04:05.123+08:00", ParseAtom},
+		{"Fri, 12 Apr 2019 07:04:05.5 GMT", ParseRfc7231},
+		// 缺少秒或时区
+		{"2019-04-12T15:04+08:00", ParseW3c},
+		{"2019-04-12T15:04:05", ParseIso8601},
+		{"Fri, 12 Apr 2019 07:04:05 +0000", ParseRfc7231},
+		{"2019-04-12 15:04:05", ParseDateTimeMilli},
+		// 大小写或补零不符
+		{"fri, 12 apr 2019 15:04:05 +0800", ParseRss},
+		{"Fri, 12 Apr 19 15:04:05 +0800", ParseRfc2822},
+		{"2019-04-12T15:04:05.1234567891Z", ParseRfc3339},
+		{"Fri, Apr 12, 2019 03:04 PM", ParseDayDateTime},
+	}
+	for _, test := range tests {
+		_, err := test.parse(test.value, time.UTC)
+		as.Equal(ErrTimeParse, err, test.value)
+	}
+}
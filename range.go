@@ -0,0 +1,268 @@
+package carbon
+
+import (
+	"sort"
+	"time"
+)
+
+// Range 时间区间，StartOpen、EndOpen 为 true 时不包含对应端点，零值两端均包含
+type Range struct {
+	Start, End         *Carbon
+	StartOpen, EndOpen bool
+}
+
+// NewRange 返回左闭右开区间 [start, end)，相邻的预约、排班不会重叠
+func NewRange(start, end *Carbon) *Range {
+	return &Range{Start: start, End: end, EndOpen: true}
+}
+
+// newRange 返回复制端点后的区间，修改结果不影响原区间
+func newRange(start *Carbon, startOpen bool, end *Carbon, endOpen bool) *Range {
+	return &Range{CreateFromGo(start.time), CreateFromGo(end.time), startOpen, endOpen}
+}
+
+// IsEmpty 判断区间是否不包含任何时间
+func (r *Range) IsEmpty() bool {
+	return r.End.time.Before(r.Start.time) || r.End.time.Equal(r.Start.time) && (r.StartOpen || r.EndOpen)
+}
+
+// Contains 判断区间是否包含 c
+func (r *Range) Contains(c *Carbon) bool {
+	if c.time.Before(r.Start.time) || c.time.After(r.End.time) {
+		return false
+	}
+	return !(r.StartOpen && c.time.Equal(r.Start.time) || r.EndOpen && c.time.Equal(r.End.time))
+}
+
+// Duration 返回区间的时长，空区间返回 0
+func (r *Range) Duration() time.Duration {
+	if r.IsEmpty() {
+		return 0
+	}
+	return r.End.time.Sub(r.Start.time)
+}
+
+// startsBefore 判断 r 的开始是否早于 o 的开始，同一时刻时包含端点的较早
+func (r *Range) startsBefore(o *Range) bool {
+	if r.Start.time.Equal(o.Start.time) {
+		return !r.StartOpen && o.StartOpen
+	}
+	return r.Start.time.Before(o.Start.time)
+}
+
+// endsAfter 判断 r 的结束是否晚于 o 的结束，同一时刻时包含端点的较晚
+func (r *Range) endsAfter(o *Range) bool {
+	if r.End.time.Equal(o.End.time) {
+		return !r.EndOpen && o.EndOpen
+	}
+	return r.End.time.After(o.End.time)
+}
+
+// Intersect 返回两个区间的交集，没有交集时返回 nil
+func (r *Range) Intersect(o *Range) *Range {
+	start, end := r, r
+	if r.startsBefore(o) {
+		start = o
+	}
+	if r.endsAfter(o) {
+		end = o
+	}
+	result := newRange(start.Start, start.StartOpen, end.End, end.EndOpen)
+	if result.IsEmpty() {
+		return nil
+	}
+	return result
+}
+
+// Overlaps 判断两个区间是否有交集
+func (r *Range) Overlaps(o *Range) bool {
+	return r.Intersect(o) != nil
+}
+
+// connected 判断两个区间是否重叠或首尾相接，如 [1, 2) 与 [2, 3)
+func (r *Range) connected(o *Range) bool {
+	first, second := r, o
+	if o.startsBefore(r) {
+		first, second = o, r
+	}
+	if second.Start.time.Equal(first.End.time) {
+		return !first.EndOpen || !second.StartOpen
+	}
+	return second.Start.time.Before(first.End.time)
+}
+
+// Union 返回两个区间的并集，两个区间既不重叠也不相接时返回 nil，多个区间的并集使用 RangeSet
+func (r *Range) Union(o *Range) *Range {
+	if r.IsEmpty() || o.IsEmpty() || !r.connected(o) {
+		return nil
+	}
+	start, end := o, o
+	if r.startsBefore(o) {
+		start = r
+	}
+	if r.endsAfter(o) {
+		end = r
+	}
+	return newRange(start.Start, start.StartOpen, end.End, end.EndOpen)
+}
+
+// Subtract 返回区间去掉 o 后剩余的部分，可能为 0 到 2 个区间
+func (r *Range) Subtract(o *Range) []*Range {
+	if r.IsEmpty() {
+		return nil
+	}
+	inter := r.Intersect(o)
+	if inter == nil {
+		return []*Range{newRange(r.Start, r.StartOpen, r.End, r.EndOpen)}
+	}
+	var result []*Range
+	if left := newRange(r.Start, r.StartOpen, inter.Start, !inter.StartOpen); !left.IsEmpty() {
+		result = append(result, left)
+	}
+	if right := newRange(inter.End, !inter.EndOpen, r.End, r.EndOpen); !right.IsEmpty() {
+		result = append(result, right)
+	}
+	return result
+}
+
+// Gap 返回两个区间之间的空隙，重叠或相接时返回 nil
+func (r *Range) Gap(o *Range) *Range {
+	if r.IsEmpty() || o.IsEmpty() || r.connected(o) {
+		return nil
+	}
+	first, second := r, o
+	if o.startsBefore(r) {
+		first, second = o, r
+	}
+	return newRange(first.End, !first.EndOpen, second.Start, !second.StartOpen)
+}
+
+// Split 按单位的日历边界拆分区间，如按天拆分时在每天当地 00:00 处拆分，周从星期一开始，
+// 中间的区间均为左闭右开，第一个、最后一个保留原区间的端点，unit 不支持时返回 nil
+func (r *Range) Split(unit Unit) []*Range {
+	if r.IsEmpty() {
+		return nil
+	}
+	base := r.Start.Floor(unit).time
+	if !addUnit(base, unit, 1).After(base) {
+		return nil
+	}
+	var result []*Range
+	start, startOpen := r.Start, r.StartOpen
+	for i := 1; ; i++ {
+		boundary := addUnit(base, unit, i)
+		if !boundary.Before(r.End.time) {
+			break
+		}
+		if !boundary.After(start.time) {
+			continue
+		}
+		result = append(result, newRange(start, startOpen, CreateFromGo(boundary), true))
+		start, startOpen = CreateFromGo(boundary), false
+	}
+	return append(result, newRange(start, startOpen, r.End, r.EndOpen))
+}
+
+// String 返回区间的字符串，如 "[2019-04-12 09:00:00, 2019-04-12 10:00:00)"
+func (r *Range) String() string {
+	left, right := "[", "]"
+	if r.StartOpen {
+		left = "("
+	}
+	if r.EndOpen {
+		right = ")"
+	}
+	return left + r.Start.ToDateTimeString() + ", " + r.End.ToDateTimeString() + right
+}
+
+// RangeSet 区间集合，区间按开始时间排序，重叠或相接的区间会合并
+type RangeSet struct {
+	ranges []*Range
+}
+
+// NewRangeSet 由多个区间生成集合，忽略空区间
+func NewRangeSet(ranges ...*Range) *RangeSet {
+	return (&RangeSet{}).Add(ranges...)
+}
+
+// Add 加入区间并重新合并
+func (s *RangeSet) Add(ranges ...*Range) *RangeSet {
+	all := append([]*Range{}, s.ranges...)
+	for _, r := range ranges {
+		if !r.IsEmpty() {
+			all = append(all, newRange(r.Start, r.StartOpen, r.End, r.EndOpen))
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].startsBefore(all[j]) })
+	s.ranges = s.ranges[:0]
+	for _, r := range all {
+		if n := len(s.ranges); n > 0 && s.ranges[n-1].connected(r) {
+			s.ranges[n-1] = s.ranges[n-1].Union(r)
+			continue
+		}
+		s.ranges = append(s.ranges, r)
+	}
+	return s
+}
+
+// Ranges 返回合并后的区间
+func (s *RangeSet) Ranges() []*Range {
+	return append([]*Range{}, s.ranges...)
+}
+
+// Contains 判断集合是否包含 c
+func (s *RangeSet) Contains(c *Carbon) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool { return !s.ranges[i].End.time.Before(c.time) })
+	return i < len(s.ranges) && s.ranges[i].Contains(c)
+}
+
+// Overlaps 判断集合是否与区间有交集
+func (s *RangeSet) Overlaps(r *Range) bool {
+	for _, item := range s.ranges {
+		if item.Overlaps(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Duration 返回集合的总时长
+func (s *RangeSet) Duration() time.Duration {
+	var total time.Duration
+	for _, r := range s.ranges {
+		total += r.Duration()
+	}
+	return total
+}
+
+// FreeSlots 返回 window 中不属于集合的空闲区间，指定 min 时只返回不短于 min 的区间
+func (s *RangeSet) FreeSlots(window *Range, min ...time.Duration) []*Range {
+	var free []*Range
+	rest := []*Range{newRange(window.Start, window.StartOpen, window.End, window.EndOpen)}
+	for _, r := range s.ranges {
+		if len(rest) == 0 {
+			break
+		}
+		parts := rest[0].Subtract(r)
+		rest = rest[:0]
+		for _, p := range parts {
+			// 区间已排序，r 之前的部分不会再被之后的区间占用
+			if !p.End.time.After(r.Start.time) {
+				free = append(free, p)
+			} else {
+				rest = append(rest, p)
+			}
+		}
+	}
+	free = append(free, rest...)
+	if len(min) == 0 {
+		return free
+	}
+	var result []*Range
+	for _, r := range free {
+		if r.Duration() >= min[0] {
+			result = append(result, r)
+		}
+	}
+	return result
+}
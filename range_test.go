@@ -0,0 +1,158 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// at 返回 2019-04-12 当天 hour:minute 的 UTC 时间
+func at(hour, minute int) *Carbon {
+	return CreateFromGo(time.Date(2019, 4, 12, hour, minute, 0, 0, time.UTC))
+}
+
+// rangeStrings 返回区间的字符串
+func rangeStrings(ranges []*Range) []string {
+	var result []string
+	for _, r := range ranges {
+		result = append(result, r.String())
+	}
+	return result
+}
+
+func TestRange_Contains(t *testing.T) {
+	as := assert.New(t)
+	closed := &Range{Start: at(9, 0), End: at(10, 0)}
+	halfOpen := NewRange(at(9, 0), at(10, 0))
+	open := &Range{Start: at(9, 0), End: at(10, 0), StartOpen: true, EndOpen: true}
+
+	as.True(closed.Contains(at(9, 0)))
+	as.True(closed.Contains(at(10, 0)))
+	as.True(halfOpen.Contains(at(9, 0)))
+	as.False(halfOpen.Contains(at(10, 0)))
+	as.False(open.Contains(at(9, 0)))
+	as.True(open.Contains(at(9, 30)))
+	as.False(closed.Contains(at(10, 1)))
+
+	as.Equal(time.Hour, closed.Duration())
+	as.Equal("[2019-04-12 09:00:00, 2019-04-12 10:00:00)", halfOpen.String())
+	as.Equal("(2019-04-12 09:00:00, 2019-04-12 10:00:00)", open.String())
+
+	as.False((&Range{Start: at(9, 0), End: at(9, 0)}).IsEmpty())
+	as.True(NewRange(at(9, 0), at(9, 0)).IsEmpty())
+	as.True(NewRange(at(10, 0), at(9, 0)).IsEmpty())
+	as.Equal(time.Duration(0), NewRange(at(10, 0), at(9, 0)).Duration())
+}
+
+func TestRange_Overlaps(t *testing.T) {
+	as := assert.New(t)
+	as.True(NewRange(at(9, 0), at(10, 0)).Overlaps(NewRange(at(9, 30), at(11, 0))))
+	// 左闭右开的相邻区间不重叠
+	as.False(NewRange(at(9, 0), at(10, 0)).Overlaps(NewRange(at(10, 0), at(11, 0))))
+	as.True((&Range{Start: at(9, 0), End: at(10, 0)}).Overlaps(NewRange(at(10, 0), at(11, 0))))
+	as.False(NewRange(at(9, 0), at(10, 0)).Overlaps(NewRange(at(11, 0), at(12, 0))))
+
+	inter := NewRange(at(9, 0), at(10, 0)).Intersect(&Range{Start: at(9, 30), End: at(11, 0), StartOpen: true})
+	as.Equal("(2019-04-12 09:30:00, 2019-04-12 10:00:00)", inter.String())
+	as.Equal("[2019-04-12 10:00:00, 2019-04-12 10:00:00]", (&Range{Start: at(9, 0), End: at(10, 0)}).Intersect(&Range{Start: at(10, 0), End: at(11, 0)}).String())
+	as.Nil(NewRange(at(9, 0), at(10, 0)).Intersect(NewRange(at(10, 0), at(11, 0))))
+}
+
+func TestRange_Union(t *testing.T) {
+	as := assert.New(t)
+	as.Equal("[2019-04-12 09:00:00, 2019-04-12 11:00:00)", NewRange(at(9, 0), at(10, 0)).Union(NewRange(at(10, 0), at(11, 0))).String())
+	as.Equal("[2019-04-12 09:00:00, 2019-04-12 11:00:00]", (&Range{Start: at(9, 30), End: at(11, 0)}).Union(NewRange(at(9, 0), at(10, 0))).String())
+	as.Nil(NewRange(at(9, 0), at(10, 0)).Union(NewRange(at(11, 0), at(12, 0))))
+	// 两端都不包含 10:00 时中间缺少一个时刻
+	as.Nil((&Range{Start: at(9, 0), End: at(10, 0), EndOpen: true}).Union(&Range{Start: at(10, 0), End: at(11, 0), StartOpen: true}))
+}
+
+func TestRange_Subtract(t *testing.T) {
+	as := assert.New(t)
+	day := NewRange(at(9, 0), at(18, 0))
+	as.Equal([]string{
+		"[2019-04-12 09:00:00, 2019-04-12 12:00:00)",
+		"[2019-04-12 13:00:00, 2019-04-12 18:00:00)",
+	}, rangeStrings(day.Subtract(NewRange(at(12, 0), at(13, 0)))))
+	as.Equal([]string{"[2019-04-12 10:00:00, 2019-04-12 18:00:00)"}, rangeStrings(day.Subtract(NewRange(at(8, 0), at(10, 0)))))
+	as.Equal([]string{"[2019-04-12 09:00:00, 2019-04-12 18:00:00)"}, rangeStrings(day.Subtract(NewRange(at(19, 0), at(20, 0)))))
+	as.Empty(day.Subtract(NewRange(at(8, 0), at(19, 0))))
+	as.Equal([]string{
+		"[2019-04-12 09:00:00, 2019-04-12 12:00:00)",
+		"(2019-04-12 12:00:00, 2019-04-12 18:00:00)",
+	}, rangeStrings(day.Subtract(&Range{Start: at(12, 0), End: at(12, 0)})))
+}
+
+func TestRange_Gap(t *testing.T) {
+	as := assert.New(t)
+	as.Equal("[2019-04-12 10:00:00, 2019-04-12 11:00:00)", NewRange(at(11, 0), at(12, 0)).Gap(NewRange(at(9, 0), at(10, 0))).String())
+	as.Nil(NewRange(at(9, 0), at(10, 0)).Gap(NewRange(at(10, 0), at(11, 0))))
+	as.Nil(NewRange(at(9, 0), at(10, 0)).Gap(NewRange(at(9, 30), at(11, 0))))
+	as.Equal("[2019-04-12 10:00:00, 2019-04-12 10:00:00]", (&Range{Start: at(9, 0), End: at(10, 0), EndOpen: true}).Gap(&Range{Start: at(10, 0), End: at(11, 0), StartOpen: true}).String())
+}
+
+func TestRange_Split(t *testing.T) {
+	as := assert.New(t)
+	r := NewRange(at(22, 30), CreateFromGo(time.Date(2019, 4, 14, 8, 0, 0, 0, time.UTC)))
+	as.Equal([]string{
+		"[2019-04-12 22:30:00, 2019-04-13 00:00:00)",
+		"[2019-04-13 00:00:00, 2019-04-14 00:00:00)",
+		"[2019-04-14 00:00:00, 2019-04-14 08:00:00)",
+	}, rangeStrings(r.Split(Day)))
+	as.Equal([]string{"[2019-04-12 22:30:00, 2019-04-14 08:00:00)"}, rangeStrings(r.Split(Week)))
+
+	r = &Range{Start: CreateFromGo(time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC)), End: CreateFromGo(time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC))}
+	as.Equal([]string{
+		"[2019-01-31 00:00:00, 2019-02-01 00:00:00)",
+		"[2019-02-01 00:00:00, 2019-03-01 00:00:00]",
+	}, rangeStrings(r.Split(Month)))
+	as.Equal(3, len(NewRange(at(9, 15), at(11, 45)).Split(Hour)))
+	as.Empty(NewRange(at(10, 0), at(9, 0)).Split(Hour))
+	// 不支持的单位
+	as.Nil(NewRange(at(9, 15), at(11, 45)).Split(Unit(99)))
+
+	// 按当地日期拆分，夏令时当天为 23 小时
+	ny, _ := time.LoadLocation("America/New_York")
+	parts := NewRange(CreateFromGo(time.Date(2019, 3, 9, 12, 0, 0, 0, ny)), CreateFromGo(time.Date(2019, 3, 11, 12, 0, 0, 0, ny))).Split(Day)
+	as.Equal(3, len(parts))
+	as.Equal(23*time.Hour, parts[1].Duration())
+}
+
+func TestRangeSet(t *testing.T) {
+	as := assert.New(t)
+	busy := NewRangeSet(
+		NewRange(at(13, 0), at(14, 0)),
+		NewRange(at(9, 0), at(10, 0)),
+		NewRange(at(9, 30), at(10, 30)),
+		NewRange(at(10, 30), at(11, 0)),
+		NewRange(at(16, 0), at(16, 0)),
+	)
+	as.Equal([]string{
+		"[2019-04-12 09:00:00, 2019-04-12 11:00:00)",
+		"[2019-04-12 13:00:00, 2019-04-12 14:00:00)",
+	}, rangeStrings(busy.Ranges()))
+	as.Equal(3*time.Hour, busy.Duration())
+	as.True(busy.Contains(at(10, 59)))
+	as.False(busy.Contains(at(11, 0)))
+	as.False(busy.Contains(at(8, 0)))
+	as.True(busy.Overlaps(NewRange(at(13, 30), at(15, 0))))
+	as.False(busy.Overlaps(NewRange(at(11, 0), at(13, 0))))
+
+	window := NewRange(at(8, 0), at(18, 0))
+	as.Equal([]string{
+		"[2019-04-12 08:00:00, 2019-04-12 09:00:00)",
+		"[2019-04-12 11:00:00, 2019-04-12 13:00:00)",
+		"[2019-04-12 14:00:00, 2019-04-12 18:00:00)",
+	}, rangeStrings(busy.FreeSlots(window)))
+	as.Equal([]string{
+		"[2019-04-12 11:00:00, 2019-04-12 13:00:00)",
+		"[2019-04-12 14:00:00, 2019-04-12 18:00:00)",
+	}, rangeStrings(busy.FreeSlots(window, 90*time.Minute)))
+	as.Equal([]string{"[2019-04-12 11:00:00, 2019-04-12 12:00:00)"}, rangeStrings(busy.FreeSlots(NewRange(at(10, 0), at(12, 0)))))
+
+	busy.Add(NewRange(at(11, 0), at(13, 0)))
+	as.Equal([]string{"[2019-04-12 09:00:00, 2019-04-12 14:00:00)"}, rangeStrings(busy.Ranges()))
+	as.Empty(NewRangeSet().Ranges())
+	as.Equal([]string{"[2019-04-12 08:00:00, 2019-04-12 18:00:00)"}, rangeStrings(NewRangeSet().FreeSlots(window)))
+}
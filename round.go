@@ -0,0 +1,124 @@
+package carbon
+
+import "time"
+
+// gregorianMonday 0001-01-01(星期一)到 1970-01-01 的秒数，用于按周对齐
+const gregorianMonday = 62135596800
+
+// roundBounds 返回墙上时间 w(以 UTC 表示)所在的 n 个 unit 的区间 [start, next)。
+// 年按 n 的倍数对齐，周从 weekStart 开始按 n 的倍数对齐，其余单位在上一级单位内对齐，
+// 如 15 分钟为每小时的 00、15、30、45 分，5 小时为每天的 0、5、10、15、20 点，最后一段到上一级单位结束
+func roundBounds(w time.Time, unit Unit, n int, weekStart time.Weekday) (start, next time.Time) {
+	y, m, d := w.Date()
+	switch unit {
+	case Year:
+		start = time.Date(floorDiv(y, n)*n, 1, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(n, 0, 0)
+	case QuarterUnit, Month:
+		size := n
+		if unit == QuarterUnit {
+			size = 3 * n
+		}
+		start = time.Date(y, time.Month((int(m)-1)/size*size+1), 1, 0, 0, 0, 0, time.UTC)
+		next = start.AddDate(0, size, 0)
+		if end := time.Date(y+1, 1, 1, 0, 0, 0, 0, time.UTC); next.After(end) {
+			next = end
+		}
+		return start, next
+	case Week:
+		offset := (int(weekStart) - int(time.Monday) + 7) % 7
+		days := floorDiv(int((w.Unix()+gregorianMonday)/86400)-offset, 7*n)*7*n + offset
+		start = time.Date(1, 1, 1+days, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 0, 7*n)
+	case Day:
+		start = time.Date(y, m, (d-1)/n*n+1, 0, 0, 0, 0, time.UTC)
+		next = start.AddDate(0, 0, n)
+		if end := time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC); next.After(end) {
+			next = end
+		}
+		return start, next
+	}
+
+	var parent time.Time
+	var parentSize, size time.Duration
+	switch unit {
+	case Hour:
+		parent, parentSize, size = time.Date(y, m, d, 0, 0, 0, 0, time.UTC), 24*time.Hour, time.Hour
+	case Minute:
+		parent, parentSize, size = w.Truncate(time.Hour), time.Hour, time.Minute
+	case Second:
+		parent, parentSize, size = w.Truncate(time.Minute), time.Minute, time.Second
+	case Millisecond:
+		parent, parentSize, size = w.Truncate(time.Second), time.Second, time.Millisecond
+	case Microsecond:
+		parent, parentSize, size = w.Truncate(time.Second), time.Second, time.Microsecond
+	default:
+		parent, parentSize, size = w.Truncate(time.Second), time.Second, time.Nanosecond
+	}
+	step := time.Duration(n) * size
+	start = parent.Add(w.Sub(parent) / step * step)
+	next = start.Add(step)
+	if end := parent.Add(parentSize); next.After(end) {
+		next = end
+	}
+	return start, next
+}
+
+// multiple 返回可选参数 n，默认为 1
+func multiple(n []int) int {
+	if len(n) > 0 && n[0] > 1 {
+		return n[0]
+	}
+	return 1
+}
+
+// roundInstants 返回 c 所在区间的开始及下一个区间开始对应的时刻，按时间排序。
+// 夏令时结束时重复的墙上时间有两个时刻，夏令时开始时跳过的墙上时间使用调整的时刻
+func (c *Carbon) roundInstants(unit Unit, n int, weekStart time.Weekday) []time.Time {
+	loc := c.time.Location()
+	start, next := roundBounds(wallClock(c.time).Add(time.Duration(c.time.Nanosecond())), unit, n, weekStart)
+	return append(wallInstants(start, loc), wallInstants(next, loc)...)
+}
+
+// floor 返回不晚于 c 的最近的区间开始
+func (c *Carbon) floor(unit Unit, n int, weekStart time.Weekday) time.Time {
+	var result time.Time
+	for i, t := range c.roundInstants(unit, n, weekStart) {
+		if i == 0 || !t.After(c.time) {
+			result = t
+		}
+	}
+	return result
+}
+
+// ceil 返回不早于 c 的最近的区间开始
+func (c *Carbon) ceil(unit Unit, n int, weekStart time.Weekday) time.Time {
+	instants := c.roundInstants(unit, n, weekStart)
+	result := instants[len(instants)-1]
+	for i := len(instants) - 1; i >= 0; i-- {
+		if !instants[i].Before(c.time) {
+			result = instants[i]
+		}
+	}
+	return result
+}
+
+// Floor 返回向下取整到 n 个 unit 的时间，n 默认为 1，如 Floor(Minute, 15) 返回所在 15 分钟的开始。
+// 按当地时间计算，天从当地 00:00 开始，周从星期一开始，季度使用 QuarterUnit
+func (c *Carbon) Floor(unit Unit, n ...int) *Carbon {
+	return CreateFromGo(c.floor(unit, multiple(n), time.Monday))
+}
+
+// Ceil 返回向上取整到 n 个 unit 的时间，n 默认为 1，已对齐时返回相同的时间
+func (c *Carbon) Ceil(unit Unit, n ...int) *Carbon {
+	return CreateFromGo(c.ceil(unit, multiple(n), time.Monday))
+}
+
+// Round 返回四舍五入到 n 个 unit 的时间，n 默认为 1，按实际时长比较，正好在中间时向上取整
+func (c *Carbon) Round(unit Unit, n ...int) *Carbon {
+	floor, ceil := c.Floor(unit, n...), c.Ceil(unit, n...)
+	if c.time.Sub(floor.time) < ceil.time.Sub(c.time) {
+		return floor
+	}
+	return ceil
+}
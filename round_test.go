@@ -0,0 +1,107 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarbon_Floor(t *testing.T) {
+	as := assert.New(t)
+	c := CreateFromGo(time.Date(2019, 8, 14, 15, 38, 27, 123456789, time.UTC))
+	layout := "2006-01-02 15:04:05.000000000"
+	tests := []struct {
+		unit       Unit
+		n          int
+		floor, cei string
+	}{
+		{Year, 1, "2019-01-01 00:00:00.000000000", "2020-01-01 00:00:00.000000000"},
+		{Year, 10, "2010-01-01 00:00:00.000000000", "2020-01-01 00:00:00.000000000"},
+		{QuarterUnit, 1, "2019-07-01 00:00:00.000000000", "2019-10-01 00:00:00.000000000"},
+		{QuarterUnit, 2, "2019-07-01 00:00:00.000000000", "2020-01-01 00:00:00.000000000"},
+		{Month, 1, "2019-08-01 00:00:00.000000000", "2019-09-01 00:00:00.000000000"},
+		{Month, 5, "2019-06-01 00:00:00.000000000", "2019-11-01 00:00:00.000000000"},
+		{Week, 1, "2019-08-12 00:00:00.000000000", "2019-08-19 00:00:00.000000000"},
+		{Day, 1, "2019-08-14 00:00:00.000000000", "2019-08-15 00:00:00.000000000"},
+		{Day, 10, "2019-08-11 00:00:00.000000000", "2019-08-21 00:00:00.000000000"},
+		{Hour, 1, "2019-08-14 15:00:00.000000000", "2019-08-14 16:00:00.000000000"},
+		{Hour, 5, "2019-08-14 15:00:00.000000000", "2019-08-14 20:00:00.000000000"},
+		{Minute, 15, "2019-08-14 15:30:00.000000000", "2019-08-14 15:45:00.000000000"},
+		{Second, 10, "2019-08-14 15:38:20.000000000", "2019-08-14 15:38:30.000000000"},
+		{Millisecond, 100, "2019-08-14 15:38:27.100000000", "2019-08-14 15:38:27.200000000"},
+		{Microsecond, 1, "2019-08-14 15:38:27.123456000", "2019-08-14 15:38:27.123457000"},
+		{Nanosecond, 1, "2019-08-14 15:38:27.123456789", "2019-08-14 15:38:27.123456789"},
+	}
+	for _, test := range tests {
+		as.Equal(test.floor, c.Floor(test.unit, test.n).Format(layout), test.unit, test.n)
+		as.Equal(test.cei, c.Ceil(test.unit, test.n).Format(layout), test.unit, test.n)
+	}
+	// 最后一段到上一级单位结束
+	as.Equal("2019-08-15 00:00:00", Create(2019, 8, 14, 22, 0, 0, time.UTC).Ceil(Hour, 5).ToDateTimeString())
+	as.Equal("2019-09-01 00:00:00", Create(2019, 8, 31, 12, 0, 0, time.UTC).Ceil(Day, 10).ToDateTimeString())
+	// 已对齐时不变，默认 n 为 1
+	aligned := Create(2019, 8, 14, 15, 30, 0, time.UTC)
+	as.Equal("2019-08-14 15:30:00", aligned.Ceil(Minute, 15).ToDateTimeString())
+	as.Equal("2019-08-14 15:30:00", aligned.Floor(Minute).ToDateTimeString())
+	// 不修改原时间
+	as.Equal("2019-08-14 15:38:27", c.ToDateTimeString())
+}
+
+func TestCarbon_Round(t *testing.T) {
+	as := assert.New(t)
+	tests := []struct {
+		value string
+		unit  Unit
+		n     int
+		want  string
+	}{
+		{"2019-08-14 15:38:27", Minute, 15, "2019-08-14 15:45:00"},
+		{"2019-08-14 15:37:29", Minute, 15, "2019-08-14 15:30:00"},
+		{"2019-08-14 15:37:30", Minute, 15, "2019-08-14 15:45:00"},
+		{"2019-08-14 15:29:59", Hour, 1, "2019-08-14 15:00:00"},
+		{"2019-08-14 15:30:00", Hour, 1, "2019-08-14 16:00:00"},
+		{"2019-08-14 11:59:59", Day, 1, "2019-08-14 00:00:00"},
+		{"2019-08-16 12:00:00", Month, 1, "2019-09-01 00:00:00"},
+		{"2019-05-15 00:00:00", QuarterUnit, 1, "2019-04-01 00:00:00"},
+		{"2019-07-03 00:00:00", Year, 1, "2020-01-01 00:00:00"},
+	}
+	for _, test := range tests {
+		c := Parse("2006-01-02 15:04:05", test.value)
+		as.Equal(test.want, c.Round(test.unit, test.n).ToDateTimeString(), test.value)
+	}
+}
+
+func TestCarbon_RoundTimeZone(t *testing.T) {
+	as := assert.New(t)
+	format := "2006-01-02 15:04 MST"
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	// 按当地 00:00 取整，而不是 UTC
+	c := CreateFromGo(time.Date(2019, 8, 14, 7, 30, 0, 0, shanghai))
+	as.Equal("2019-08-14 00:00 CST", c.Floor(Day).Format(format))
+	as.Equal("2019-08-12 00:00 CST", c.Floor(Week).Format(format))
+
+	// 印度为 +05:30，小时按当地时间对齐
+	kolkata, _ := time.LoadLocation("Asia/Kolkata")
+	as.Equal("2019-08-14 07:00 IST", CreateFromGo(time.Date(2019, 8, 14, 7, 30, 0, 0, kolkata)).Floor(Hour).Format(format))
+
+	ny, _ := time.LoadLocation("America/New_York")
+	// 2019-03-10 当天只有 23 小时，02:00 不存在
+	spring := CreateFromGo(time.Date(2019, 3, 10, 3, 30, 0, 0, ny))
+	as.Equal("2019-03-10 00:00 EST", spring.Floor(Day).Format(format))
+	as.Equal("2019-03-11 00:00 EDT", spring.Ceil(Day).Format(format))
+	as.Equal("2019-03-10 03:00 EDT", spring.Floor(Hour, 2).Format(format))
+	as.Equal("2019-03-10 03:00 EDT", spring.Floor(Hour).Format(format))
+
+	// 2019-11-03 01:00 重复两次，按实际时间取整
+	first := CreateFromGo(time.Date(2019, 11, 3, 5, 40, 0, 0, time.UTC).In(ny))
+	second := CreateFromGo(time.Date(2019, 11, 3, 6, 40, 0, 0, time.UTC).In(ny))
+	as.Equal("2019-11-03 01:40 EDT", first.Format(format))
+	as.Equal("2019-11-03 01:00 EDT", first.Floor(Hour).Format(format))
+	as.Equal("2019-11-03 01:00 EST", first.Ceil(Hour).Format(format))
+	as.Equal("2019-11-03 01:00 EST", second.Floor(Hour).Format(format))
+	as.Equal("2019-11-03 02:00 EST", second.Ceil(Hour).Format(format))
+	as.Equal("2019-11-03 01:30 EST", second.Floor(Minute, 30).Format(format))
+	as.Equal("2019-11-03 00:00 EDT", second.Floor(Day).Format(format))
+	as.Equal(25*time.Hour, second.Ceil(Day).time.Sub(second.Floor(Day).time))
+}
@@ -0,0 +1,1139 @@
+package carbon
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency RRULE 的重复频率
+type Frequency int
+
+const (
+	// Yearly 每年
+	Yearly Frequency = iota
+	// Monthly 每月
+	Monthly
+	// Weekly 每周
+	Weekly
+	// Daily 每天
+	Daily
+	// Hourly 每小时
+	Hourly
+	// Minutely 每分钟
+	Minutely
+	// Secondly 每秒
+	Secondly
+)
+
+var (
+	frequencyNames = []string{"YEARLY", "MONTHLY", "WEEKLY", "DAILY", "HOURLY", "MINUTELY", "SECONDLY"}
+	rruleWeekdays  = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+)
+
+// rruleGiveUp 连续这么长时间没有结果时停止查找，公历每 400 年循环一次
+const rruleGiveUp = 400 * 366 * 86400
+
+// String 返回频率的名称，如 "WEEKLY"
+func (f Frequency) String() string {
+	if f < Yearly || f > Secondly {
+		return ""
+	}
+	return frequencyNames[f]
+}
+
+// RWeekday BYDAY 中的星期，N 不为 0 时表示第 N 个，负数表示倒数第 N 个，如 2TU、-1FR
+type RWeekday struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// String 返回 BYDAY 的写法，如 "2TU"
+func (w RWeekday) String() string {
+	if w.N == 0 {
+		return rruleWeekdays[w.Weekday]
+	}
+	return strconv.Itoa(w.N) + rruleWeekdays[w.Weekday]
+}
+
+// RRule RFC 5545 的重复规则
+type RRule struct {
+	Freq Frequency
+	// Dtstart 开始时间，也决定时区及未指定的月、日、星期、时、分、秒，为 nil 时使用现在
+	Dtstart *Carbon
+	// Interval 间隔，0 视为 1
+	Interval int
+	// Count 重复次数，0 表示不限
+	Count int
+	// Until 结束时间(包含)，nil 表示不限
+	Until *Carbon
+	// Wkst 一周的第一天，ParseRRule 未指定 WKST 时为星期一
+	Wkst time.Weekday
+
+	BySetPos, ByMonth, ByMonthDay, ByYearDay, ByWeekNo []int
+	ByDay                                              []RWeekday
+	ByHour, ByMinute, BySecond                         []int
+
+	// untilDate UNTIL 是否是日期，是时包含当天
+	untilDate bool
+}
+
+// splitICalLine 拆分 iCalendar 内容行，返回大写的名称、参数及值，没有名称时 name 为空，参数值可以带双引号
+func splitICalLine(line string) (name string, params map[string]string, value string) {
+	quoted, start := false, 0
+	var parts []string
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == ';' || c == ':':
+			parts = append(parts, line[start:i])
+			start = i + 1
+			if c == ':' {
+				params = map[string]string{}
+				for _, p := range parts[1:] {
+					if kv := strings.SplitN(p, "=", 2); len(kv) == 2 {
+						params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+					}
+				}
+				return strings.ToUpper(parts[0]), params, line[i+1:]
+			}
+		}
+	}
+	return "", nil, line
+}
+
+// icalLines 拆分多行文本，去掉空行
+func icalLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseICalTime 解析 iCalendar 的 DATE 或 DATE-TIME，如 "19970902"、"19970902T090000Z"、"19970902T090000"，
+// 没有 Z 的时间按参数 TZID 或 loc 解析，返回是否是日期
+func parseICalTime(value string, params map[string]string, loc *time.Location) (time.Time, bool, error) {
+	if tzid := params["TZID"]; tzid != "" {
+		l, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, ErrTimeParse
+		}
+		loc = l
+	}
+	var t time.Time
+	var err error
+	switch {
+	case len(value) == 8:
+		t, err = time.ParseInLocation("20060102", value, loc)
+	case strings.HasSuffix(value, "Z"):
+		t, err = time.Parse("20060102T150405Z", value)
+	default:
+		t, err = time.ParseInLocation("20060102T150405", value, loc)
+	}
+	if err != nil {
+		return time.Time{}, false, ErrTimeParse
+	}
+	return t, len(value) == 8, nil
+}
+
+// icalTimeValue 返回 iCalendar DATE-TIME 的 TZID 参数及值，UTC 时间带 Z，本地时间不带时区，
+// 这两种情况 TZID 为空，其他时区使用时区名称作为 TZID
+func icalTimeValue(t time.Time) (tzid, value string) {
+	switch t.Location() {
+	case time.UTC:
+		return "", t.Format("20060102T150405Z")
+	case time.Local:
+		return "", t.Format("20060102T150405")
+	}
+	return t.Location().String(), t.Format("20060102T150405")
+}
+
+// ParseRRule 解析重复规则，如 "FREQ=MONTHLY;BYDAY=2TU"，可以带 "RRULE:" 前缀，
+// 也可以包含 "DTSTART;TZID=Asia/Shanghai:20190412T090000" 一行
+func ParseRRule(value string) (*RRule, error) {
+	var dtstart *Carbon
+	rule := ""
+	for _, line := range icalLines(value) {
+		name, params, v := splitICalLine(line)
+		switch name {
+		case "DTSTART":
+			t, _, err := parseICalTime(v, params, time.Local)
+			if err != nil {
+				return nil, ErrRRuleParse
+			}
+			dtstart = CreateFromGo(t)
+		case "RRULE", "":
+			rule = v
+		default:
+			return nil, ErrRRuleParse
+		}
+	}
+	return parseRRuleValue(rule, dtstart)
+}
+
+// parseRRuleInts 解析以逗号分隔的整数，取值范围为 [min, max]，allowNegative 时也可以是 [-max, -min]
+func parseRRuleInts(value string, min, max int, allowNegative bool) ([]int, error) {
+	var result []int
+	for _, s := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(s)
+		if err != nil || (n < min || n > max) && (!allowNegative || n > -min || n < -max) {
+			return nil, ErrRRuleParse
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+// parseRRuleWeekday 解析 RRULE 中的星期，如 "MO"
+func parseRRuleWeekday(s string) (time.Weekday, bool) {
+	for i, name := range rruleWeekdays {
+		if strings.ToUpper(s) == name {
+			return time.Weekday(i), true
+		}
+	}
+	return time.Sunday, false
+}
+
+// parseRRuleValue 解析 RRULE 的值
+func parseRRuleValue(value string, dtstart *Carbon) (*RRule, error) {
+	r := &RRule{Dtstart: dtstart, Interval: 1, Wkst: time.Monday}
+	loc := time.Local
+	if dtstart != nil {
+		loc = dtstart.time.Location()
+	}
+	hasFreq := false
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, ErrRRuleParse
+		}
+		var err error
+		v := kv[1]
+		switch strings.ToUpper(kv[0]) {
+		case "FREQ":
+			hasFreq = false
+			for i, name := range frequencyNames {
+				if strings.ToUpper(v) == name {
+					r.Freq, hasFreq = Frequency(i), true
+				}
+			}
+			if !hasFreq {
+				return nil, ErrRRuleParse
+			}
+		case "INTERVAL":
+			if r.Interval, err = strconv.Atoi(v); err != nil || r.Interval < 1 {
+				return nil, ErrRRuleParse
+			}
+		case "COUNT":
+			if r.Count, err = strconv.Atoi(v); err != nil || r.Count < 1 {
+				return nil, ErrRRuleParse
+			}
+		case "UNTIL":
+			t, date, err := parseICalTime(v, nil, loc)
+			if err != nil {
+				return nil, ErrRRuleParse
+			}
+			r.Until, r.untilDate = CreateFromGo(t), date
+		case "WKST":
+			var ok bool
+			if r.Wkst, ok = parseRRuleWeekday(v); !ok {
+				return nil, ErrRRuleParse
+			}
+		case "BYSETPOS":
+			r.BySetPos, err = parseRRuleInts(v, 1, 366, true)
+		case "BYMONTH":
+			r.ByMonth, err = parseRRuleInts(v, 1, 12, false)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseRRuleInts(v, 1, 31, true)
+		case "BYYEARDAY":
+			r.ByYearDay, err = parseRRuleInts(v, 1, 366, true)
+		case "BYWEEKNO":
+			r.ByWeekNo, err = parseRRuleInts(v, 1, 53, true)
+		case "BYHOUR":
+			r.ByHour, err = parseRRuleInts(v, 0, 23, false)
+		case "BYMINUTE":
+			r.ByMinute, err = parseRRuleInts(v, 0, 59, false)
+		case "BYSECOND":
+			r.BySecond, err = parseRRuleInts(v, 0, 59, false)
+		case "BYDAY":
+			for _, s := range strings.Split(v, ",") {
+				if len(s) < 2 {
+					return nil, ErrRRuleParse
+				}
+				weekday, ok := parseRRuleWeekday(s[len(s)-2:])
+				n := 0
+				if prefix := s[:len(s)-2]; prefix != "" {
+					if n, err = strconv.Atoi(prefix); err != nil || n == 0 || n < -53 || n > 53 {
+						return nil, ErrRRuleParse
+					}
+				}
+				if !ok {
+					return nil, ErrRRuleParse
+				}
+				r.ByDay = append(r.ByDay, RWeekday{weekday, n})
+			}
+		default:
+			return nil, ErrRRuleParse
+		}
+		if err != nil {
+			return nil, ErrRRuleParse
+		}
+	}
+	// COUNT 与 UNTIL 不能同时出现
+	if !hasFreq || r.Count > 0 && r.Until != nil {
+		return nil, ErrRRuleParse
+	}
+	return r, nil
+}
+
+// String 返回 RRULE 的值，如 "FREQ=MONTHLY;BYDAY=2TU"，不包含 DTSTART
+func (r *RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		if r.untilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.time.UTC().Format("20060102T150405Z"))
+		}
+	}
+	if r.Wkst != time.Monday {
+		parts = append(parts, "WKST="+rruleWeekdays[r.Wkst])
+	}
+	ints := func(name string, values []int) {
+		if len(values) == 0 {
+			return
+		}
+		s := make([]string, len(values))
+		for i, v := range values {
+			s[i] = strconv.Itoa(v)
+		}
+		parts = append(parts, name+"="+strings.Join(s, ","))
+	}
+	ints("BYSETPOS", r.BySetPos)
+	ints("BYMONTH", r.ByMonth)
+	ints("BYMONTHDAY", r.ByMonthDay)
+	ints("BYYEARDAY", r.ByYearDay)
+	ints("BYWEEKNO", r.ByWeekNo)
+	if len(r.ByDay) > 0 {
+		s := make([]string, len(r.ByDay))
+		for i, w := range r.ByDay {
+			s[i] = w.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(s, ","))
+	}
+	ints("BYHOUR", r.ByHour)
+	ints("BYMINUTE", r.ByMinute)
+	ints("BYSECOND", r.BySecond)
+	return strings.Join(parts, ";")
+}
+
+// RRuleIterator 按时间顺序返回重复的时间
+type RRuleIterator struct {
+	next func() (time.Time, bool)
+}
+
+// Next 返回下一次重复的时间，没有时返回 nil
+func (it *RRuleIterator) Next() *Carbon {
+	t, ok := it.next()
+	if !ok {
+		return nil
+	}
+	return CreateFromGo(t)
+}
+
+// between 返回迭代器在 after 与 before 之间的时间，inclusive 时包含两端
+func between(next func() (time.Time, bool), after, before *Carbon, inclusive bool) []*Carbon {
+	var result []*Carbon
+	for t, ok := next(); ok; t, ok = next() {
+		if t.After(before.time) || !inclusive && t.Equal(before.time) {
+			break
+		}
+		if t.After(after.time) || inclusive && t.Equal(after.time) {
+			result = append(result, CreateFromGo(t))
+		}
+	}
+	return result
+}
+
+// Iterator 返回按时间顺序的迭代器
+func (r *RRule) Iterator() *RRuleIterator {
+	return &RRuleIterator{newRRuleGen(r, nil).next}
+}
+
+// Between 返回 after 与 before 之间的重复时间，inclusive 时包含两端
+func (r *RRule) Between(after, before *Carbon, inclusive bool) []*Carbon {
+	return between(newRRuleGen(r, nil).next, after, before, inclusive)
+}
+
+// All 返回全部重复时间，只能用于有 COUNT 或 UNTIL 的规则，最多返回 limit 个
+func (r *RRule) All(limit int) []*Carbon {
+	var result []*Carbon
+	g := newRRuleGen(r, nil)
+	for t, ok := g.next(); ok && len(result) < limit; t, ok = g.next() {
+		result = append(result, CreateFromGo(t))
+	}
+	return result
+}
+
+// rruleGen 按 RFC 5545 逐个周期计算重复时间
+type rruleGen struct {
+	freq     Frequency
+	interval int64
+	count    int
+	start    time.Time
+	until    *time.Time
+	loc      *time.Location
+	wkst     time.Weekday
+
+	bySetPos, byMonth, byMonthDay, byYearDay, byWeekNo, byHour, byMinute, bySecond []int
+	weekdays                                                                       [7]bool
+	hasWeekdays                                                                    bool
+	nth                                                                            []RWeekday
+
+	// base 第 0 个周期开始的墙上时间，以 UTC 表示
+	base    time.Time
+	period  int64
+	lastHit time.Time
+	buffer  []time.Time
+	emitted int
+	done    bool
+}
+
+// newRRuleGen 根据规则生成计算器，规则没有 Dtstart 时使用 dtstart，仍为 nil 时使用现在
+func newRRuleGen(r *RRule, dtstart *Carbon) *rruleGen {
+	if r.Dtstart != nil {
+		dtstart = r.Dtstart
+	}
+	if dtstart == nil {
+		dtstart = Now()
+	}
+	start := dtstart.time.Truncate(time.Second)
+	g := &rruleGen{
+		freq:       r.Freq,
+		interval:   int64(r.Interval),
+		count:      r.Count,
+		start:      start,
+		loc:        start.Location(),
+		wkst:       r.Wkst,
+		bySetPos:   r.BySetPos,
+		byMonth:    r.ByMonth,
+		byMonthDay: r.ByMonthDay,
+		byYearDay:  r.ByYearDay,
+		byWeekNo:   r.ByWeekNo,
+		byHour:     r.ByHour,
+		byMinute:   r.ByMinute,
+		bySecond:   r.BySecond,
+	}
+	if g.interval < 1 {
+		g.interval = 1
+	}
+	if r.Until != nil {
+		until := r.Until.time
+		if r.untilDate {
+			y, m, d := until.Date()
+			until = time.Date(y, m, d+1, 0, 0, 0, 0, g.loc).Add(-time.Nanosecond)
+		}
+		g.until = &until
+	}
+	// 序号只用于每月、每年(未指定 BYWEEKNO)，其余情况视为普通星期
+	for _, w := range r.ByDay {
+		if w.N != 0 && (g.freq == Monthly || g.freq == Yearly && len(g.byWeekNo) == 0) {
+			g.nth = append(g.nth, w)
+		} else {
+			g.weekdays[w.Weekday], g.hasWeekdays = true, true
+		}
+	}
+
+	// 未指定的部分使用 Dtstart 的值
+	if len(g.byWeekNo) == 0 && len(g.byYearDay) == 0 && len(g.byMonthDay) == 0 && len(r.ByDay) == 0 {
+		switch g.freq {
+		case Yearly:
+			if len(g.byMonth) == 0 {
+				g.byMonth = []int{int(start.Month())}
+			}
+			g.byMonthDay = []int{start.Day()}
+		case Monthly:
+			g.byMonthDay = []int{start.Day()}
+		case Weekly:
+			g.weekdays[start.Weekday()], g.hasWeekdays = true, true
+		}
+	}
+	if len(g.byHour) == 0 && g.freq < Hourly {
+		g.byHour = []int{start.Hour()}
+	}
+	if len(g.byMinute) == 0 && g.freq < Minutely {
+		g.byMinute = []int{start.Minute()}
+	}
+	if len(g.bySecond) == 0 && g.freq < Secondly {
+		g.bySecond = []int{start.Second()}
+	}
+	g.byHour, g.byMinute, g.bySecond = sortedInts(g.byHour), sortedInts(g.byMinute), sortedInts(g.bySecond)
+
+	w := wallClock(start)
+	switch g.freq {
+	case Yearly:
+		g.base = time.Date(w.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	case Monthly:
+		g.base = time.Date(w.Year(), w.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case Weekly:
+		g.base = time.Date(w.Year(), w.Month(), w.Day()-(int(w.Weekday())-int(g.wkst)+7)%7, 0, 0, 0, 0, time.UTC)
+	case Daily:
+		g.base = time.Date(w.Year(), w.Month(), w.Day(), 0, 0, 0, 0, time.UTC)
+	case Hourly:
+		g.base = w.Truncate(time.Hour)
+	case Minutely:
+		g.base = w.Truncate(time.Minute)
+	default:
+		g.base = w
+	}
+	g.lastHit = g.base
+	return g
+}
+
+// sortedInts 返回排序去重后的副本
+func sortedInts(values []int) []int {
+	result := append([]int{}, values...)
+	sort.Ints(result)
+	for i := len(result) - 1; i > 0; i-- {
+		if result[i] == result[i-1] {
+			result = append(result[:i], result[i+1:]...)
+		}
+	}
+	return result
+}
+
+// containsInt 判断 values 是否包含 n
+func containsInt(values []int, n int) bool {
+	for _, v := range values {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesIndex 判断 1 开始的序号 pos 是否满足 values，负数表示倒数，size 为总数
+func matchesIndex(values []int, pos, size int) bool {
+	for _, v := range values {
+		if v == pos || v < 0 && size+v+1 == pos {
+			return true
+		}
+	}
+	return false
+}
+
+// next 返回下一次重复的时间
+func (g *rruleGen) next() (time.Time, bool) {
+	for len(g.buffer) == 0 {
+		if g.done {
+			return time.Time{}, false
+		}
+		g.fill()
+	}
+	t := g.buffer[0]
+	g.buffer = g.buffer[1:]
+	if g.until != nil && t.After(*g.until) || g.count > 0 && g.emitted >= g.count {
+		g.done, g.buffer = true, nil
+		return time.Time{}, false
+	}
+	g.emitted++
+	return t, true
+}
+
+// periodStart 返回第 k 个周期开始的墙上时间
+func (g *rruleGen) periodStart(k int64) time.Time {
+	n := k * g.interval
+	switch g.freq {
+	case Yearly:
+		return g.base.AddDate(int(n), 0, 0)
+	case Monthly:
+		return g.base.AddDate(0, int(n), 0)
+	case Weekly:
+		return g.base.AddDate(0, 0, int(n)*7)
+	case Daily:
+		return g.base.AddDate(0, 0, int(n))
+	}
+	return time.Unix(g.base.Unix()+n*g.unitSeconds(), 0).UTC()
+}
+
+// unitSeconds 返回每小时、每分钟、每秒的周期秒数
+func (g *rruleGen) unitSeconds() int64 {
+	switch g.freq {
+	case Hourly:
+		return 3600
+	case Minutely:
+		return 60
+	}
+	return 1
+}
+
+// skipTo 跳到第一个不早于墙上时间 t 的周期，用于每小时、每分钟、每秒
+func (g *rruleGen) skipTo(t time.Time) {
+	step := g.interval * g.unitSeconds()
+	g.period = (t.Unix() - g.base.Unix() + step - 1) / step
+}
+
+// fill 计算下一个周期的重复时间
+func (g *rruleGen) fill() {
+	p := g.periodStart(g.period)
+	if p.Year() > 9999 || p.Unix()-g.lastHit.Unix() > rruleGiveUp {
+		g.done = true
+		return
+	}
+
+	var days []time.Time
+	switch g.freq {
+	case Yearly:
+		days = dayRange(p, p.AddDate(1, 0, 0))
+	case Monthly:
+		days = dayRange(p, p.AddDate(0, 1, 0))
+	case Weekly:
+		days = dayRange(p, p.AddDate(0, 0, 7))
+	case Daily:
+		days = []time.Time{p}
+	default:
+		// 每小时、每分钟、每秒不满足的日期、小时、分钟直接跳过
+		y, m, d := p.Date()
+		switch {
+		case !g.dayMatches(p):
+			g.skipTo(time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC))
+		case len(g.byHour) > 0 && !containsInt(g.byHour, p.Hour()):
+			g.skipTo(time.Date(y, m, d, p.Hour()+1, 0, 0, 0, time.UTC))
+		case g.freq > Hourly && len(g.byMinute) > 0 && !containsInt(g.byMinute, p.Minute()):
+			g.skipTo(time.Date(y, m, d, p.Hour(), p.Minute()+1, 0, 0, time.UTC))
+		case g.freq > Minutely && len(g.bySecond) > 0 && !containsInt(g.bySecond, p.Second()):
+			g.period++
+		default:
+			g.emit(g.times(p))
+			g.period++
+		}
+		return
+	}
+
+	var occurrences []time.Time
+	for _, day := range days {
+		if g.dayMatches(day) {
+			occurrences = append(occurrences, g.times(day)...)
+		}
+	}
+	g.emit(occurrences)
+	g.period++
+}
+
+// dayRange 返回 [from, to) 之间的每一天
+func dayRange(from, to time.Time) []time.Time {
+	var days []time.Time
+	for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+	}
+	return days
+}
+
+// times 返回某天按 BYHOUR、BYMINUTE、BYSECOND 组合的时间，每小时及更短的频率使用周期本身的时、分、秒
+func (g *rruleGen) times(p time.Time) []time.Time {
+	hours, minutes, seconds := g.byHour, g.byMinute, g.bySecond
+	if g.freq >= Hourly {
+		hours = []int{p.Hour()}
+	}
+	if g.freq >= Minutely {
+		minutes = []int{p.Minute()}
+	}
+	if g.freq >= Secondly {
+		seconds = []int{p.Second()}
+	}
+	y, m, d := p.Date()
+	var result []time.Time
+	for _, h := range hours {
+		for _, mi := range minutes {
+			for _, s := range seconds {
+				if g.freq >= Hourly {
+					result = append(result, rruleWallTimes(y, m, d, h, mi, s, g.loc)...)
+				} else {
+					result = append(result, rruleDate(y, m, d, h, mi, s, g.loc))
+				}
+			}
+		}
+	}
+	return result
+}
+
+// rruleDate 返回 loc 时区的墙上时间，夏令时跳过的时间按跳过前的时差计算，即顺延跳过的时长
+func rruleDate(y int, m time.Month, d, h, mi, s int, loc *time.Location) time.Time {
+	t := time.Date(y, m, d, h, mi, s, 0, loc)
+	if t.Hour() == h && t.Minute() == mi {
+		return t
+	}
+	_, offset := t.Zone()
+	return time.Unix(time.Date(y, m, d, h, mi, s, 0, time.UTC).Unix()-int64(offset), 0).In(loc)
+}
+
+// rruleWallTimes 返回 loc 时区的墙上时间对应的所有时刻，用于每小时、每分钟、每秒，
+// 夏令时跳过的时间不存在，返回空，重复的时间返回两个时刻
+func rruleWallTimes(y int, m time.Month, d, h, mi, s int, loc *time.Location) []time.Time {
+	wall := time.Date(y, m, d, h, mi, s, 0, time.UTC)
+	var result []time.Time
+	for _, near := range []time.Time{wall.Add(-24 * time.Hour), wall.Add(24 * time.Hour)} {
+		_, offset := near.In(loc).Zone()
+		t := time.Unix(wall.Unix()-int64(offset), 0).In(loc)
+		wy, wm, wd := t.Date()
+		if wy != y || wm != m || wd != d || t.Hour() != h || t.Minute() != mi || t.Second() != s {
+			continue
+		}
+		if len(result) == 0 || !t.Equal(result[0]) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// emit 对一个周期的时间应用 BYSETPOS，去掉 Dtstart 之前的时间后加入缓冲区
+func (g *rruleGen) emit(occurrences []time.Time) {
+	sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].Before(occurrences[j]) })
+	if len(g.bySetPos) > 0 {
+		var selected []time.Time
+		for i, t := range occurrences {
+			if matchesIndex(g.bySetPos, i+1, len(occurrences)) {
+				selected = append(selected, t)
+			}
+		}
+		occurrences = selected
+	}
+	for i, t := range occurrences {
+		if !t.Before(g.start) && (i == 0 || !t.Equal(occurrences[i-1])) {
+			g.buffer = append(g.buffer, t)
+		}
+	}
+	if len(occurrences) > 0 {
+		g.lastHit = g.periodStart(g.period)
+	}
+}
+
+// dayMatches 判断某天是否满足 BYMONTH、BYWEEKNO、BYYEARDAY、BYMONTHDAY、BYDAY
+func (g *rruleGen) dayMatches(day time.Time) bool {
+	y, m, d := day.Date()
+	if len(g.byMonth) > 0 && !containsInt(g.byMonth, int(m)) {
+		return false
+	}
+	monthDays := time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	yearDays := time.Date(y, 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	if len(g.byWeekNo) > 0 && !g.weekNoMatches(day) {
+		return false
+	}
+	if len(g.byYearDay) > 0 && !matchesIndex(g.byYearDay, day.YearDay(), yearDays) {
+		return false
+	}
+	if len(g.byMonthDay) > 0 && !matchesIndex(g.byMonthDay, d, monthDays) {
+		return false
+	}
+	if !g.hasWeekdays && len(g.nth) == 0 {
+		return true
+	}
+	weekday := day.Weekday()
+	if g.weekdays[weekday] {
+		return true
+	}
+	// 每月或指定了 BYMONTH 时为当月的第几个，否则为当年的第几个
+	pos, size := day.YearDay(), yearDays
+	if g.freq == Monthly || len(g.byMonth) > 0 {
+		pos, size = d, monthDays
+	}
+	for _, w := range g.nth {
+		if w.Weekday == weekday && (w.N > 0 && (pos-1)/7+1 == w.N || w.N < 0 && (size-pos)/7+1 == -w.N) {
+			return true
+		}
+	}
+	return false
+}
+
+// week1Start 返回 year 年第 1 周的第一天，第 1 周是至少有 4 天在当年的第一周
+func (g *rruleGen) week1Start(year int) time.Time {
+	jan1 := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(jan1.Weekday()) - int(g.wkst) + 7) % 7
+	start := jan1.AddDate(0, 0, -offset)
+	if 7-offset < 4 {
+		start = start.AddDate(0, 0, 7)
+	}
+	return start
+}
+
+// weekNoMatches 判断某天所在的周是否满足 BYWEEKNO
+func (g *rruleGen) weekNoMatches(day time.Time) bool {
+	year := day.Year()
+	if day.Before(g.week1Start(year)) {
+		year--
+	} else if !day.Before(g.week1Start(year + 1)) {
+		year++
+	}
+	start, next := g.week1Start(year), g.week1Start(year+1)
+	week := int(day.Sub(start).Hours()/24)/7 + 1
+	return matchesIndex(g.byWeekNo, week, int(next.Sub(start).Hours()/24)/7)
+}
+
+// RRuleSet 由 RRULE、RDATE、EXRULE、EXDATE 组成的重复时间集合
+type RRuleSet struct {
+	// Dtstart 开始时间，规则没有 Dtstart 时使用
+	Dtstart         *Carbon
+	RRules, ExRules []*RRule
+	RDates, ExDates []*Carbon
+}
+
+// ParseRRuleSet 解析多行的 DTSTART、RRULE、RDATE、EXRULE、EXDATE
+func ParseRRuleSet(text string) (*RRuleSet, error) {
+	set := &RRuleSet{}
+	lines := icalLines(text)
+	loc := time.Local
+	for _, line := range lines {
+		if name, params, v := splitICalLine(line); name == "DTSTART" {
+			t, _, err := parseICalTime(v, params, time.Local)
+			if err != nil {
+				return nil, ErrRRuleParse
+			}
+			set.Dtstart, loc = CreateFromGo(t), t.Location()
+		}
+	}
+	for _, line := range lines {
+		name, params, v := splitICalLine(line)
+		switch name {
+		case "DTSTART":
+		case "RRULE", "EXRULE":
+			r, err := parseRRuleValue(v, set.Dtstart)
+			if err != nil {
+				return nil, err
+			}
+			if name == "RRULE" {
+				set.RRules = append(set.RRules, r)
+			} else {
+				set.ExRules = append(set.ExRules, r)
+			}
+		case "RDATE", "EXDATE":
+			for _, s := range strings.Split(v, ",") {
+				t, _, err := parseICalTime(s, params, loc)
+				if err != nil {
+					return nil, ErrRRuleParse
+				}
+				if name == "RDATE" {
+					set.RDates = append(set.RDates, CreateFromGo(t))
+				} else {
+					set.ExDates = append(set.ExDates, CreateFromGo(t))
+				}
+			}
+		default:
+			return nil, ErrRRuleParse
+		}
+	}
+	return set, nil
+}
+
+// String 返回多行的 DTSTART、RRULE、RDATE、EXRULE、EXDATE，RDATE、EXDATE 使用 UTC 时间
+func (s *RRuleSet) String() string {
+	var lines []string
+	if s.Dtstart != nil {
+		line := "DTSTART"
+		tzid, value := icalTimeValue(s.Dtstart.time)
+		if tzid != "" {
+			line += ";TZID=" + tzid
+		}
+		lines = append(lines, line+":"+value)
+	}
+	dates := func(name string, values []*Carbon) {
+		if len(values) == 0 {
+			return
+		}
+		parts := make([]string, len(values))
+		for i, c := range values {
+			parts[i] = c.time.UTC().Format("20060102T150405Z")
+		}
+		lines = append(lines, name+":"+strings.Join(parts, ","))
+	}
+	for _, r := range s.RRules {
+		lines = append(lines, "RRULE:"+r.String())
+	}
+	dates("RDATE", s.RDates)
+	for _, r := range s.ExRules {
+		lines = append(lines, "EXRULE:"+r.String())
+	}
+	dates("EXDATE", s.ExDates)
+	return strings.Join(lines, "\n")
+}
+
+// rruleHead 计算器及其下一个时间
+type rruleHead struct {
+	gen *rruleGen
+	t   time.Time
+	ok  bool
+}
+
+// Iterator 返回按时间顺序的迭代器，相同的时间只返回一次
+func (s *RRuleSet) Iterator() *RRuleIterator {
+	heads := func(rules []*RRule) []*rruleHead {
+		result := make([]*rruleHead, len(rules))
+		for i, r := range rules {
+			h := &rruleHead{gen: newRRuleGen(r, s.Dtstart)}
+			h.t, h.ok = h.gen.next()
+			result[i] = h
+		}
+		return result
+	}
+	includes, excludes := heads(s.RRules), heads(s.ExRules)
+	rdates := make([]time.Time, len(s.RDates))
+	for i, c := range s.RDates {
+		rdates[i] = c.time
+	}
+	sort.Slice(rdates, func(i, j int) bool { return rdates[i].Before(rdates[j]) })
+	exdates := map[instantKey]bool{}
+	for _, c := range s.ExDates {
+		exdates[keyOf(c.time)] = true
+	}
+
+	var last time.Time
+	started := false
+	next := func() (time.Time, bool) {
+		for {
+			// 取 RRULE 及 RDATE 中最早的时间
+			var min *rruleHead
+			for _, h := range includes {
+				if h.ok && (min == nil || h.t.Before(min.t)) {
+					min = h
+				}
+			}
+			var t time.Time
+			switch {
+			case len(rdates) > 0 && (min == nil || !min.t.Before(rdates[0])):
+				t, rdates = rdates[0], rdates[1:]
+			case min != nil:
+				t = min.t
+				min.t, min.ok = min.gen.next()
+			default:
+				return time.Time{}, false
+			}
+			if started && t.Equal(last) || exdates[keyOf(t)] {
+				continue
+			}
+			excluded := false
+			for _, h := range excludes {
+				for h.ok && h.t.Before(t) {
+					h.t, h.ok = h.gen.next()
+				}
+				excluded = excluded || h.ok && h.t.Equal(t)
+			}
+			if excluded {
+				continue
+			}
+			last, started = t, true
+			return t, true
+		}
+	}
+	return &RRuleIterator{next}
+}
+
+// Between 返回 after 与 before 之间的重复时间，inclusive 时包含两端
+func (s *RRuleSet) Between(after, before *Carbon, inclusive bool) []*Carbon {
+	return between(s.Iterator().next, after, before, inclusive)
+}
+
+// rruleUnits 各频率的英文单位
+var rruleUnits = []string{"year", "month", "week", "day", "hour", "minute", "second"}
+
+// rruleChineseUnits 各频率的中文单位
+var rruleChineseUnits = []string{"年", "个月", "周", "天", "小时", "分钟", "秒"}
+
+// Describe 返回规则的自然语言描述，locale 为 zh 时返回中文，如 "每月第二个周二"，否则返回英文，如 "every month on the 2nd Tuesday"
+func (r *RRule) Describe(locale string) string {
+	if language(locale) == "zh" {
+		return r.describeChinese()
+	}
+	return r.describeEnglish()
+}
+
+// joinEnglish 以逗号及 and 连接
+func joinEnglish(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+// rruleHours 返回 BYHOUR 与 BYMINUTE 组合的时刻，如 "9:00"，没有 BYHOUR 时返回 nil
+func (r *RRule) rruleHours() []string {
+	var times []string
+	minute := 0
+	if len(r.ByMinute) > 0 {
+		minute = r.ByMinute[0]
+	} else if r.Dtstart != nil {
+		minute = r.Dtstart.time.Minute()
+	}
+	for _, h := range r.ByHour {
+		times = append(times, strconv.Itoa(h)+":"+pad(minute, 2))
+	}
+	return times
+}
+
+// describeEnglish 返回英文描述
+func (r *RRule) describeEnglish() string {
+	f := dateFormatsOf("en")
+	ordinal := func(n int) string {
+		switch {
+		case n == -1:
+			return "last"
+		case n < 0:
+			return f.ordinal(-n) + " to last"
+		}
+		return f.ordinal(n)
+	}
+	unit := rruleUnits[r.Freq]
+	s := "every " + unit
+	if r.Interval > 1 {
+		s = "every " + strconv.Itoa(r.Interval) + " " + unit + "s"
+	}
+	if len(r.ByWeekNo) > 0 {
+		weeks := make([]string, len(r.ByWeekNo))
+		for i, n := range r.ByWeekNo {
+			weeks[i] = strconv.Itoa(n)
+		}
+		s += " in week " + joinEnglish(weeks)
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]string, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = f.Months[m-1]
+		}
+		s += " in " + joinEnglish(months)
+	}
+	var days []string
+	for _, n := range r.ByYearDay {
+		days = append(days, "the "+ordinal(n)+" day of the year")
+	}
+	for _, n := range r.ByMonthDay {
+		if n == -1 {
+			days = append(days, "the last day")
+		} else if n < 0 {
+			days = append(days, "the "+ordinal(n)+" day")
+		} else {
+			days = append(days, "the "+ordinal(n))
+		}
+	}
+	for _, w := range r.ByDay {
+		if w.N == 0 {
+			days = append(days, f.Weekdays[w.Weekday])
+		} else {
+			days = append(days, "the "+ordinal(w.N)+" "+f.Weekdays[w.Weekday])
+		}
+	}
+	if len(days) > 0 {
+		s += " on " + joinEnglish(days)
+	}
+	if times := r.rruleHours(); len(times) > 0 {
+		s += " at " + joinEnglish(times)
+	}
+	if len(r.BySetPos) > 0 {
+		positions := make([]string, len(r.BySetPos))
+		for i, n := range r.BySetPos {
+			positions[i] = ordinal(n)
+		}
+		s += " (the " + joinEnglish(positions) + " one)"
+	}
+	switch {
+	case r.Count == 1:
+		s += ", once"
+	case r.Count > 1:
+		s += ", " + strconv.Itoa(r.Count) + " times"
+	case r.Until != nil:
+		s += ", until " + f.Months[r.Until.time.Month()-1] + " " + strconv.Itoa(r.Until.time.Day()) + ", " + strconv.Itoa(r.Until.time.Year())
+	}
+	return s
+}
+
+// describeChinese 返回中文描述
+func (r *RRule) describeChinese() string {
+	f := dateFormatsOf("zh")
+	ordinal := func(n int, unit string) string {
+		switch {
+		case n == -1:
+			return "最后一" + unit
+		case n < 0:
+			return "倒数第" + chineseNumber(-n) + unit
+		}
+		return "第" + chineseNumber(n) + unit
+	}
+	s := "每" + strings.TrimPrefix(rruleChineseUnits[r.Freq], "个")
+	if r.Interval > 1 {
+		s = "每" + strconv.Itoa(r.Interval) + rruleChineseUnits[r.Freq]
+	}
+	// 周数、月份在每年之后，比年短的频率放在频率之前，如 "2月的每周"
+	var parts []string
+	if len(r.ByWeekNo) > 0 {
+		weeks := make([]string, len(r.ByWeekNo))
+		for i, n := range r.ByWeekNo {
+			weeks[i] = ordinal(n, "周")
+		}
+		parts = append(parts, strings.Join(weeks, "、"))
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]string, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = strconv.Itoa(m) + "月"
+		}
+		parts = append(parts, strings.Join(months, "、"))
+	}
+	if r.Freq != Yearly && len(parts) > 0 {
+		s = strings.Join(parts, "") + "的" + s
+		parts = nil
+	}
+	var days []string
+	for _, n := range r.ByYearDay {
+		days = append(days, ordinal(n, "天"))
+	}
+	for _, n := range r.ByMonthDay {
+		if n > 0 {
+			days = append(days, strconv.Itoa(n)+"日")
+		} else {
+			days = append(days, ordinal(n, "天"))
+		}
+	}
+	for _, w := range r.ByDay {
+		if w.N == 0 {
+			days = append(days, f.ShortWeekdays[w.Weekday])
+		} else {
+			days = append(days, ordinal(w.N, "个")+f.ShortWeekdays[w.Weekday])
+		}
+	}
+	if len(days) > 0 {
+		if r.Freq == Weekly {
+			s += "的"
+		}
+		parts = append(parts, strings.Join(days, "、"))
+	}
+	s += strings.Join(parts, "")
+	if times := r.rruleHours(); len(times) > 0 {
+		s += " " + strings.Join(times, "、")
+	}
+	if len(r.BySetPos) > 0 {
+		positions := make([]string, len(r.BySetPos))
+		for i, n := range r.BySetPos {
+			positions[i] = ordinal(n, "次")
+		}
+		s += "（" + strings.Join(positions, "、") + "）"
+	}
+	switch {
+	case r.Count > 0:
+		s += "，共" + strconv.Itoa(r.Count) + "次"
+	case r.Until != nil:
+		s += "，直到" + strconv.Itoa(r.Until.time.Year()) + "年" + strconv.Itoa(int(r.Until.time.Month())) + "月" + strconv.Itoa(r.Until.time.Day()) + "日"
+	}
+	return s
+}
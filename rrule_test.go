@@ -0,0 +1,230 @@
+package carbon
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rruleDates 返回规则的前 n 个时间，格式为 "2006-01-02 15:04"
+func rruleDates(it *RRuleIterator, n int) []string {
+	var result []string
+	for c := it.Next(); c != nil && len(result) < n; c = it.Next() {
+		result = append(result, c.Format("2006-01-02 15:04"))
+	}
+	return result
+}
+
+func TestRRule_RFC5545(t *testing.T) {
+	as := assert.New(t)
+	dtstart := "DTSTART;TZID=America/New_York:19970902T090000\n"
+	tests := []struct {
+		rule string
+		want []string
+	}{
+		{"FREQ=DAILY;COUNT=3", []string{"1997-09-02 09:00", "1997-09-03 09:00", "1997-09-04 09:00"}},
+		{"FREQ=DAILY;INTERVAL=10;COUNT=3", []string{"1997-09-02 09:00", "1997-09-12 09:00", "1997-09-22 09:00"}},
+		{"FREQ=WEEKLY;UNTIL=19971007T000000Z;WKST=SU;BYDAY=TU,TH", []string{
+			"1997-09-02 09:00", "1997-09-04 09:00", "1997-09-09 09:00", "1997-09-11 09:00", "1997-09-16 09:00",
+			"1997-09-18 09:00", "1997-09-23 09:00", "1997-09-25 09:00", "1997-09-30 09:00", "1997-10-02 09:00",
+		}},
+		{"FREQ=WEEKLY;INTERVAL=2;COUNT=8;WKST=SU;BYDAY=TU,TH", []string{
+			"1997-09-02 09:00", "1997-09-04 09:00", "1997-09-16 09:00", "1997-09-18 09:00",
+			"1997-09-30 09:00", "1997-10-02 09:00", "1997-10-14 09:00", "1997-10-16 09:00",
+		}},
+		{"FREQ=MONTHLY;COUNT=6;BYDAY=-2MO", []string{
+			"1997-09-22 09:00", "1997-10-20 09:00", "1997-11-17 09:00", "1997-12-22 09:00", "1998-01-19 09:00", "1998-02-16 09:00",
+		}},
+		{"FREQ=MONTHLY;BYMONTHDAY=-3;COUNT=3", []string{"1997-09-28 09:00", "1997-10-29 09:00", "1997-11-28 09:00"}},
+		{"FREQ=MONTHLY;INTERVAL=18;COUNT=4;BYMONTHDAY=10,11", []string{"1997-09-10 09:00", "1997-09-11 09:00", "1999-03-10 09:00", "1999-03-11 09:00"}},
+		{"FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO;COUNT=3", []string{"1998-05-11 09:00", "1999-05-17 09:00", "2000-05-15 09:00"}},
+		{"FREQ=YEARLY;BYMONTH=3;BYDAY=TH;COUNT=3", []string{"1998-03-05 09:00", "1998-03-12 09:00", "1998-03-19 09:00"}},
+		{"FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13;COUNT=3", []string{"1998-02-13 09:00", "1998-03-13 09:00", "1998-11-13 09:00"}},
+		{"FREQ=MONTHLY;COUNT=3;BYDAY=TU,WE,TH;BYSETPOS=3", []string{"1997-09-04 09:00", "1997-10-07 09:00", "1997-11-06 09:00"}},
+		{"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2;COUNT=3", []string{"1997-09-29 09:00", "1997-10-30 09:00", "1997-11-27 09:00"}},
+		{"FREQ=HOURLY;INTERVAL=3;UNTIL=19970902T170000Z", []string{"1997-09-02 09:00", "1997-09-02 12:00"}},
+		{"FREQ=MINUTELY;INTERVAL=90;COUNT=4", []string{"1997-09-02 09:00", "1997-09-02 10:30", "1997-09-02 12:00", "1997-09-02 13:30"}},
+		{"FREQ=DAILY;BYHOUR=9,10,11,12,13,14,15,16;BYMINUTE=0,20,40;COUNT=4", []string{"1997-09-02 09:00", "1997-09-02 09:20", "1997-09-02 09:40", "1997-09-02 10:00"}},
+		{"FREQ=MINUTELY;INTERVAL=20;BYHOUR=9,10,11,12,13,14,15,16;COUNT=5", []string{"1997-09-02 09:00", "1997-09-02 09:20", "1997-09-02 09:40", "1997-09-02 10:00", "1997-09-02 10:20"}},
+		{"FREQ=MONTHLY;BYMONTHDAY=15,30;COUNT=5", []string{"1997-09-15 09:00", "1997-09-30 09:00", "1997-10-15 09:00", "1997-10-30 09:00", "1997-11-15 09:00"}},
+		{"FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO", []string{"1997-09-02 09:00", "1997-09-07 09:00", "1997-09-16 09:00", "1997-09-21 09:00"}},
+		{"FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU", []string{"1997-09-02 09:00", "1997-09-14 09:00", "1997-09-16 09:00", "1997-09-28 09:00"}},
+	}
+	for _, test := range tests {
+		r, err := ParseRRule(dtstart + test.rule)
+		as.Nil(err, test.rule)
+		as.Equal(test.want, rruleDates(r.Iterator(), len(test.want)+5), test.rule)
+	}
+}
+
+func TestRRule_Iterator(t *testing.T) {
+	as := assert.New(t)
+	ny, _ := time.LoadLocation("America/New_York")
+	dtstart := CreateFromGo(time.Date(1997, 9, 2, 9, 0, 0, 0, ny))
+
+	// 每年第 20 个星期一
+	r, err := ParseRRule("FREQ=YEARLY;BYDAY=20MO")
+	as.Nil(err)
+	r.Dtstart = dtstart
+	as.Equal([]string{"1998-05-18 09:00", "1999-05-17 09:00", "2000-05-15 09:00"}, rruleDates(r.Iterator(), 3))
+
+	// 每年第 1、100、200 天，每 3 年一次
+	r, _ = ParseRRule("FREQ=YEARLY;INTERVAL=3;COUNT=5;BYYEARDAY=1,100,200")
+	r.Dtstart = CreateFromGo(time.Date(1997, 1, 1, 9, 0, 0, 0, ny))
+	as.Equal([]string{"1997-01-01 09:00", "1997-04-10 09:00", "1997-07-19 09:00", "2000-01-01 09:00", "2000-04-09 09:00"}, rruleDates(r.Iterator(), 10))
+
+	// 美国总统选举日
+	r, _ = ParseRRule("FREQ=YEARLY;INTERVAL=4;BYMONTH=11;BYDAY=TU;BYMONTHDAY=2,3,4,5,6,7,8")
+	r.Dtstart = CreateFromGo(time.Date(1996, 11, 5, 9, 0, 0, 0, ny))
+	as.Equal([]string{"1996-11-05 09:00", "2000-11-07 09:00", "2004-11-02 09:00"}, rruleDates(r.Iterator(), 3))
+
+	// 闰日只在闰年出现
+	r, _ = ParseRRule("FREQ=YEARLY")
+	r.Dtstart = CreateFromGo(time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC))
+	as.Equal([]string{"2020-02-29 00:00", "2024-02-29 00:00", "2028-02-29 00:00"}, rruleDates(r.Iterator(), 3))
+
+	// 没有结果时结束
+	r, _ = ParseRRule("FREQ=MONTHLY;BYMONTHDAY=30;BYMONTH=2")
+	r.Dtstart = dtstart
+	as.Nil(r.Iterator().Next())
+
+	// 日期形式的 UNTIL 包含当天
+	r, _ = ParseRRule("DTSTART:20190412T090000Z\nRRULE:FREQ=DAILY;UNTIL=20190414")
+	as.Equal([]string{"2019-04-12 09:00", "2019-04-13 09:00", "2019-04-14 09:00"}, rruleDates(r.Iterator(), 10))
+	as.Equal(3, len(r.All(10)))
+}
+
+func TestRRule_DST(t *testing.T) {
+	as := assert.New(t)
+	ny, _ := time.LoadLocation("America/New_York")
+	format := "2006-01-02 15:04 MST"
+
+	// 按墙上时间重复，跨越夏令时后仍为 09:00
+	r, _ := ParseRRule("FREQ=DAILY;COUNT=3")
+	r.Dtstart = CreateFromGo(time.Date(2019, 3, 9, 9, 0, 0, 0, ny))
+	var got []string
+	it := r.Iterator()
+	for c := it.Next(); c != nil; c = it.Next() {
+		got = append(got, c.Format(format))
+	}
+	as.Equal([]string{"2019-03-09 09:00 EST", "2019-03-10 09:00 EDT", "2019-03-11 09:00 EDT"}, got)
+
+	// 每天及更长的频率跳过的时间顺延
+	r.Dtstart = CreateFromGo(time.Date(2019, 3, 9, 2, 30, 0, 0, ny))
+	as.Equal("2019-03-10 03:30 EDT", r.Between(CreateFromGo(time.Date(2019, 3, 10, 0, 0, 0, 0, ny)), CreateFromGo(time.Date(2019, 3, 11, 0, 0, 0, 0, ny)), true)[0].Format(format))
+
+	// 每小时及更短的频率跳过的时间不存在，不计入 COUNT，重复的时间两次都包含
+	hourly := func(rule string, dtstart time.Time) []string {
+		r, _ := ParseRRule(rule)
+		r.Dtstart = CreateFromGo(dtstart)
+		var got []string
+		it := r.Iterator()
+		for c := it.Next(); c != nil; c = it.Next() {
+			got = append(got, c.Format(format))
+		}
+		return got
+	}
+	as.Equal([]string{"2019-03-10 00:00 EST", "2019-03-10 01:00 EST", "2019-03-10 03:00 EDT", "2019-03-10 04:00 EDT", "2019-03-10 05:00 EDT"},
+		hourly("FREQ=HOURLY;COUNT=5", time.Date(2019, 3, 10, 0, 0, 0, 0, ny)))
+	as.Equal([]string{"2019-11-03 00:00 EDT", "2019-11-03 01:00 EDT", "2019-11-03 01:00 EST", "2019-11-03 02:00 EST", "2019-11-03 03:00 EST"},
+		hourly("FREQ=HOURLY;COUNT=5", time.Date(2019, 11, 3, 0, 0, 0, 0, ny)))
+	as.Equal([]string{"2019-03-10 01:30 EST", "2019-03-10 03:00 EDT", "2019-03-10 03:30 EDT"},
+		hourly("FREQ=MINUTELY;INTERVAL=30;COUNT=3", time.Date(2019, 3, 10, 1, 30, 0, 0, ny)))
+	// 从重复时间的第二次开始
+	as.Equal([]string{"2019-11-03 01:00 EST", "2019-11-03 02:00 EST"},
+		hourly("FREQ=HOURLY;COUNT=2", time.Date(2019, 11, 3, 6, 0, 0, 0, time.UTC).In(ny)))
+}
+
+func TestRRule_Between(t *testing.T) {
+	as := assert.New(t)
+	r, _ := ParseRRule("DTSTART:20190101T090000Z\nFREQ=WEEKLY;BYDAY=MO,WE")
+	after := CreateFromGo(time.Date(2019, 4, 1, 9, 0, 0, 0, time.UTC))
+	before := CreateFromGo(time.Date(2019, 4, 10, 9, 0, 0, 0, time.UTC))
+	var got []string
+	for _, c := range r.Between(after, before, true) {
+		got = append(got, c.ToDateString())
+	}
+	as.Equal([]string{"2019-04-01", "2019-04-03", "2019-04-08", "2019-04-10"}, got)
+	as.Equal(2, len(r.Between(after, before, false)))
+}
+
+func TestRRule_String(t *testing.T) {
+	as := assert.New(t)
+	for _, rule := range []string{
+		"FREQ=DAILY;COUNT=10",
+		"FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;WKST=SU;BYDAY=MO,WE,FR",
+		"FREQ=MONTHLY;BYDAY=2TU",
+		"FREQ=MONTHLY;UNTIL=19971224;BYSETPOS=-1;BYDAY=MO,TU,WE,TH,FR",
+		"FREQ=YEARLY;BYMONTH=1,7;BYMONTHDAY=-1;BYYEARDAY=100;BYWEEKNO=-1;BYHOUR=9;BYMINUTE=30;BYSECOND=0",
+	} {
+		r, err := ParseRRule("RRULE:" + rule)
+		as.Nil(err, rule)
+		as.Equal(rule, r.String())
+	}
+	r, _ := ParseRRule("freq=daily;interval=1;wkst=mo")
+	as.Equal("FREQ=DAILY", r.String())
+
+	for _, rule := range []string{
+		"", "INTERVAL=2", "FREQ=FORTNIGHTLY", "FREQ=DAILY;COUNT=0", "FREQ=DAILY;COUNT=2;UNTIL=19971224",
+		"FREQ=DAILY;BYMONTH=13", "FREQ=DAILY;BYHOUR=-1", "FREQ=MONTHLY;BYDAY=0MO", "FREQ=MONTHLY;BYDAY=2XX",
+		"FREQ=DAILY;FOO=1", "FREQ=DAILY;UNTIL=tomorrow", "EXDATE:19970902T090000Z",
+	} {
+		_, err := ParseRRule(rule)
+		as.Equal(ErrRRuleParse, err, rule)
+	}
+}
+
+func TestRRule_Describe(t *testing.T) {
+	as := assert.New(t)
+	tests := []struct {
+		rule, en, zh string
+	}{
+		{"FREQ=MONTHLY;BYDAY=2TU", "every month on the 2nd Tuesday", "每月第二个周二"},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE", "every 2 weeks on Monday and Wednesday", "每2周的周一、周三"},
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=10", "every month on the last Friday, 10 times", "每月最后一个周五，共10次"},
+		{"FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=15;BYHOUR=9", "every year in January on the 15th at 9:00", "每年1月15日 9:00"},
+		{"FREQ=MONTHLY;BYMONTHDAY=-2;UNTIL=19971224", "every month on the 2nd to last day, until December 24, 1997", "每月倒数第二天，直到1997年12月24日"},
+		{"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", "every month on Monday, Tuesday, Wednesday, Thursday and Friday (the last one)", "每月周一、周二、周三、周四、周五（最后一次）"},
+		{"FREQ=DAILY;INTERVAL=3", "every 3 days", "每3天"},
+		{"FREQ=WEEKLY;BYMONTH=2", "every week in February", "2月的每周"},
+		{"FREQ=WEEKLY;BYMONTH=2;BYDAY=MO", "every week in February on Monday", "2月的每周的周一"},
+	}
+	for _, test := range tests {
+		r, err := ParseRRule(test.rule)
+		as.Nil(err, test.rule)
+		as.Equal(test.en, r.Describe("en"), test.rule)
+		as.Equal(test.zh, r.Describe("zh-CN"), test.rule)
+	}
+}
+
+func TestRRuleSet(t *testing.T) {
+	as := assert.New(t)
+	text := strings.Join([]string{
+		"DTSTART:20190401T090000Z",
+		"RRULE:FREQ=DAILY;COUNT=5",
+		"RRULE:FREQ=WEEKLY;COUNT=2;BYDAY=MO",
+		"RDATE:20190410T090000Z,20190403T120000Z",
+		"EXRULE:FREQ=DAILY;INTERVAL=2;COUNT=2",
+		"EXDATE:20190404T090000Z",
+	}, "\n")
+	set, err := ParseRRuleSet(text)
+	as.Nil(err)
+	as.Equal([]string{"2019-04-02 09:00", "2019-04-03 12:00", "2019-04-05 09:00", "2019-04-08 09:00", "2019-04-10 09:00"}, rruleDates(set.Iterator(), 10))
+	as.Equal(text, set.String())
+
+	between := set.Between(CreateFromGo(time.Date(2019, 4, 3, 0, 0, 0, 0, time.UTC)), CreateFromGo(time.Date(2019, 4, 8, 9, 0, 0, 0, time.UTC)), false)
+	as.Equal(2, len(between))
+
+	// 相差 2^64 纳秒的时间按 UnixNano 会相同
+	rdate := time.Date(1700, 1, 1, 0, 0, 0, 0, time.UTC)
+	far := &RRuleSet{RDates: []*Carbon{CreateFromGo(rdate)}, ExDates: []*Carbon{CreateFromGo(rdate.Add(math.MaxInt64).Add(math.MaxInt64).Add(2))}}
+	as.Equal([]string{"1700-01-01 00:00"}, rruleDates(far.Iterator(), 2))
+
+	_, err = ParseRRuleSet("RRULE:FREQ=DAILY\nRDATE:tomorrow")
+	as.Equal(ErrRRuleParse, err)
+	_, err = ParseRRuleSet("SUMMARY:meeting")
+	as.Equal(ErrRRuleParse, err)
+}
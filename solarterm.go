@@ -0,0 +1,267 @@
+package carbon
+
+import (
+	"math"
+	"time"
+)
+
+// SolarTermNames 二十四节气，按公历年内先后排序，从小寒开始
+var SolarTermNames = [24]string{
+	"小寒", "大寒", "立春", "雨水", "惊蛰", "春分",
+	"清明", "谷雨", "立夏", "小满", "芒种", "夏至",
+	"小暑", "大暑", "立秋", "处暑", "白露", "秋分",
+	"寒露", "霜降", "立冬", "小雪", "大雪", "冬至",
+}
+
+// SolarTerm 节气
+type SolarTerm struct {
+	// Name 节气名称，如 "立春"
+	Name string
+	// Index 节气在公历年内的序号，0 为小寒，23 为冬至
+	Index int
+	// Time 交节的准确时刻
+	Time *Carbon
+}
+
+// 地球日心黄经的 VSOP87 简化级数，每项为 A、B、C，取值 A*cos(B+C*τ)
+var vsopEarthL = [...][][3]float64{
+	{
+		{175347046, 0, 0}, {3341656, 4.6692568, 6283.0758500}, {34894, 4.62610, 12566.15170},
+		{3497, 2.7441, 5753.3849}, {3418, 2.8289, 3.5231}, {3136, 3.6277, 77713.7715},
+		{2676, 4.4181, 7860.4194}, {2343, 6.1352, 3930.2097}, {1324, 0.7425, 11506.7698},
+		{1273, 2.0371, 529.6910}, {1199, 1.1096, 1577.3435}, {990, 5.233, 5884.927},
+		{902, 2.045, 26.298}, {857, 3.508, 398.149}, {780, 1.179, 5223.694},
+		{753, 2.533, 5507.553}, {505, 4.583, 18849.228}, {492, 4.205, 775.523},
+		{357, 2.920, 0.067}, {317, 5.849, 11790.629}, {284, 1.899, 796.298},
+		{271, 0.315, 10977.079}, {243, 0.345, 5486.778}, {206, 4.806, 2544.314},
+		{205, 1.869, 5573.143}, {202, 2.458, 6069.777}, {156, 0.833, 213.299},
+		{132, 3.411, 2942.463}, {126, 1.083, 20.775}, {115, 0.645, 0.980},
+		{103, 0.636, 4694.003}, {102, 0.976, 15720.839}, {102, 4.267, 7.114},
+		{99, 6.21, 2146.17}, {98, 0.68, 155.42}, {86, 5.98, 161000.69},
+		{85, 1.30, 6275.96}, {85, 3.67, 71430.70}, {80, 1.81, 17260.15},
+		{79, 3.04, 12036.46}, {75, 1.76, 5088.63}, {74, 3.50, 3154.69},
+		{74, 4.68, 801.82}, {70, 0.83, 9437.76}, {62, 3.98, 8827.39},
+		{61, 1.82, 7084.90}, {57, 2.78, 6286.60}, {56, 4.39, 14143.50},
+		{56, 3.47, 6279.55}, {52, 0.19, 12139.55}, {52, 1.33, 1748.02},
+		{51, 0.28, 5856.48}, {49, 0.49, 1194.45}, {41, 5.37, 8429.24},
+		{41, 2.40, 19651.05}, {39, 6.17, 10447.39}, {37, 6.04, 10213.29},
+		{37, 2.57, 1059.38}, {36, 1.71, 2352.87}, {36, 1.78, 6812.77},
+		{33, 0.59, 17789.85}, {30, 0.44, 83996.85}, {30, 2.74, 1349.87},
+		{25, 3.16, 4690.48},
+	},
+	{
+		{628331966747, 0, 0}, {206059, 2.678235, 6283.075850}, {4303, 2.6351, 12566.1517},
+		{425, 1.590, 3.523}, {119, 5.796, 26.298}, {109, 2.966, 1577.344},
+		{93, 2.59, 18849.23}, {72, 1.14, 529.69}, {68, 1.87, 398.15},
+		{67, 4.41, 5507.55}, {59, 2.89, 5223.69}, {56, 2.17, 155.42},
+		{45, 0.40, 796.30}, {36, 0.47, 775.52}, {29, 2.65, 7.11},
+		{21, 5.34, 0.98}, {19, 1.85, 5486.78}, {19, 4.97, 213.30},
+		{17, 2.99, 6275.96}, {16, 0.03, 2544.31}, {16, 1.43, 2146.17},
+		{15, 1.21, 10977.08}, {12, 2.83, 1748.02}, {12, 3.26, 5088.63},
+		{12, 5.27, 1194.45}, {12, 2.08, 4694.00}, {11, 0.77, 553.57},
+		{10, 1.30, 6286.60}, {10, 4.24, 1349.87}, {9, 2.70, 242.73},
+		{9, 5.64, 951.72}, {8, 5.30, 2352.87}, {6, 2.65, 9437.76},
+		{6, 4.67, 4690.48},
+	},
+	{
+		{52919, 0, 0}, {8720, 1.0721, 6283.0758}, {309, 0.867, 12566.152},
+		{27, 0.05, 3.52}, {16, 5.19, 26.30}, {16, 3.68, 155.42},
+		{10, 0.76, 18849.23}, {9, 2.06, 77713.77}, {7, 0.83, 775.52},
+		{5, 4.66, 1577.34}, {4, 1.03, 7.11}, {4, 3.44, 5573.14},
+		{3, 5.14, 796.30}, {3, 6.05, 5507.55}, {3, 1.19, 242.73},
+		{3, 6.12, 529.69}, {3, 0.31, 398.15}, {3, 2.28, 553.57},
+		{2, 4.38, 5223.69}, {2, 3.75, 0.98},
+	},
+	{
+		{289, 5.844, 6283.076}, {35, 0, 0}, {17, 5.49, 12566.15},
+		{3, 5.20, 155.42}, {1, 4.72, 3.52}, {1, 5.30, 18849.23},
+		{1, 5.97, 242.73},
+	},
+	{
+		{114, 3.142, 0}, {8, 4.13, 6283.08}, {1, 3.84, 12566.15},
+	},
+	{
+		{1, 3.14, 0},
+	},
+}
+
+const (
+	// jdUnixEpoch 1970-01-01 00:00:00 UTC 的儒略日
+	jdUnixEpoch = 2440587.5
+	// jdJ2000 J2000.0 历元的儒略日
+	jdJ2000 = 2451545.0
+	// tropicalYear 回归年的天数
+	tropicalYear = 365.2422
+)
+
+// sunLongitude 返回儒略历书日 jde 时刻太阳的视黄经(度)
+func sunLongitude(jde float64) float64 {
+	tau := (jde - jdJ2000) / 365250
+	var l, p float64 = 0, 1
+	for _, series := range vsopEarthL {
+		var s float64
+		for _, term := range series {
+			s += term[0] * math.Cos(term[1]+term[2]*tau)
+		}
+		l += s * p
+		p *= tau
+	}
+	// 地心黄经，并转换到 FK5 坐标系
+	lon := l/1e8*180/math.Pi + 180 - 0.09033/3600
+
+	// 章动与光行差
+	t := tau * 10
+	omega := (125.04452 - 1934.136261*t) * math.Pi / 180
+	ls := (280.4665 + 36000.7698*t) * math.Pi / 180
+	lm := (218.3165 + 481267.8813*t) * math.Pi / 180
+	nutation := -17.20*math.Sin(omega) - 1.32*math.Sin(2*ls) - 0.23*math.Sin(2*lm) + 0.21*math.Sin(2*omega)
+	lon += (nutation - 20.4898) / 3600
+
+	return math.Mod(math.Mod(lon, 360)+360, 360)
+}
+
+// deltaT 返回 year 年力学时与世界时之差(秒)，使用 Espenak 与 Meeus 的多项式
+func deltaT(year float64) float64 {
+	switch {
+	case year < -500:
+		u := (year - 1820) / 100
+		return -20 + 32*u*u
+	case year < 500:
+		u := year / 100
+		return 10583.6 - 1014.41*u + 33.78311*u*u - 5.952053*u*u*u - 0.1798452*u*u*u*u + 0.022174192*u*u*u*u*u + 0.0090316521*u*u*u*u*u*u
+	case year < 1600:
+		u := (year - 1000) / 100
+		return 1574.2 - 556.01*u + 71.23472*u*u + 0.319781*u*u*u - 0.8503463*u*u*u*u - 0.005050998*u*u*u*u*u + 0.0083572073*u*u*u*u*u*u
+	case year < 1700:
+		t := year - 1600
+		return 120 - 0.9808*t - 0.01532*t*t + t*t*t/7129
+	case year < 1800:
+		t := year - 1700
+		return 8.83 + 0.1603*t - 0.0059285*t*t + 0.00013336*t*t*t - t*t*t*t/1174000
+	case year < 1860:
+		t := year - 1800
+		return 13.72 - 0.332447*t + 0.0068612*t*t + 0.0041116*t*t*t - 0.00037436*t*t*t*t + 0.0000121272*t*t*t*t*t - 0.0000001699*t*t*t*t*t*t + 0.000000000875*t*t*t*t*t*t*t
+	case year < 1900:
+		t := year - 1860
+		return 7.62 + 0.5737*t - 0.251754*t*t + 0.01680668*t*t*t - 0.0004473624*t*t*t*t + t*t*t*t*t/233174
+	case year < 1920:
+		t := year - 1900
+		return -2.79 + 1.494119*t - 0.0598939*t*t + 0.0061966*t*t*t - 0.000197*t*t*t*t
+	case year < 1941:
+		t := year - 1920
+		return 21.20 + 0.84493*t - 0.076100*t*t + 0.0020936*t*t*t
+	case year < 1961:
+		t := year - 1950
+		return 29.07 + 0.407*t - t*t/233 + t*t*t/2547
+	case year < 1986:
+		t := year - 1975
+		return 45.45 + 1.067*t - t*t/260 - t*t*t/718
+	case year < 2005:
+		t := year - 2000
+		return 63.86 + 0.3345*t - 0.060374*t*t + 0.0017275*t*t*t + 0.000651814*t*t*t*t + 0.00002373599*t*t*t*t*t
+	case year < 2050:
+		t := year - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	case year < 2150:
+		u := (year - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-year)
+	default:
+		u := (year - 1820) / 100
+		return -20 + 32*u*u
+	}
+}
+
+// timeToJDE 将时间转换为儒略历书日
+func timeToJDE(t time.Time) float64 {
+	jd := jdUnixEpoch + floatUnix(t)/86400
+	return jd + deltaT(2000+(jd-jdJ2000)/365.25)/86400
+}
+
+// jdeToTime 将儒略历书日转换为时间，精确到秒
+func jdeToTime(jde float64) time.Time {
+	jd := jde - deltaT(2000+(jde-jdJ2000)/365.25)/86400
+	sec := math.Floor((jd-jdUnixEpoch)*86400 + 0.5)
+	return time.Unix(int64(sec), 0)
+}
+
+// solarTermJDE 返回 jde 附近太阳视黄经为 angle 的时刻
+func solarTermJDE(jde, angle float64) float64 {
+	for i := 0; i < 50; i++ {
+		d := math.Mod(angle-sunLongitude(jde)+540, 360) - 180
+		jde += d * tropicalYear / 360
+		if math.Abs(d) < 1e-7 {
+			break
+		}
+	}
+	return jde
+}
+
+// solarTermAngle 返回第 index 个节气对应的太阳黄经
+func solarTermAngle(index int) float64 {
+	return math.Mod(float64(285+15*index), 360)
+}
+
+// solarTermOf 返回 year 年第 index 个节气，index 超出 0-23 时顺延到相邻年份
+func solarTermOf(year, index int, loc *time.Location) *SolarTerm {
+	year += floorDiv(index, 24)
+	index = ((index % 24) + 24) % 24
+	// 小寒大约在 1 月 6 日，之后每个节气相隔约 15.2 天
+	guess := jdJ2000 + float64(year-2000)*tropicalYear + 4.5 + float64(index)*tropicalYear/24
+	t := jdeToTime(solarTermJDE(guess, solarTermAngle(index))).In(loc)
+	return &SolarTerm{Name: SolarTermNames[index], Index: index, Time: CreateFromGo(t)}
+}
+
+// SolarTerms 返回 year 年的二十四节气，交节时刻使用 tz 时区
+func SolarTerms(year int, tz *time.Location) []*SolarTerm {
+	terms := make([]*SolarTerm, 0, 24)
+	for i := 0; i < 24; i++ {
+		terms = append(terms, solarTermOf(year, i, tz))
+	}
+	return terms
+}
+
+// CreateFromSolarTerm 返回 year 年名为 name 的节气交节时刻
+func CreateFromSolarTerm(year int, name string, tz *time.Location) (*Carbon, error) {
+	for i, n := range SolarTermNames {
+		if n == name {
+			return solarTermOf(year, i, tz).Time, nil
+		}
+	}
+	return &Carbon{}, ErrSolarTermName
+}
+
+// SolarTerm 返回当天所处的节气，即当天或之前最近交节的节气
+func (c *Carbon) SolarTerm() *SolarTerm {
+	loc := c.time.Location()
+	y, m, d := c.time.Date()
+	end := time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+	// 以当天结束时的太阳黄经推算最近的节气
+	lon := sunLongitude(timeToJDE(end))
+	index := int(math.Mod(lon-285+360, 360) / 15)
+	term := solarTermOf(y, index, loc)
+	// 1 月初的小寒、大寒之前的节气属于上一年
+	if !term.Time.time.Before(end) {
+		term = solarTermOf(y-1, index, loc)
+	}
+	return term
+}
+
+// IsSolarTerm 判断当天是否是节气
+func (c *Carbon) IsSolarTerm() bool {
+	t := c.SolarTerm().Time.time
+	return t.Year() == c.time.Year() && t.YearDay() == c.time.YearDay()
+}
+
+// NextSolarTerm 返回当天之后的下一个节气
+func (c *Carbon) NextSolarTerm() *SolarTerm {
+	term := c.SolarTerm()
+	return solarTermOf(term.Time.time.Year(), term.Index+1, c.time.Location())
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
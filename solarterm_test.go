@@ -0,0 +1,76 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolarTerms(t *testing.T) {
+	as := assert.New(t)
+	shanghai := time.FixedZone("CST", 8*3600)
+	tests := []struct {
+		year int
+		name string
+		want string
+	}{
+		{1900, "立春", "1900-02-04 13:51"},
+		{2019, "小寒", "2019-01-05 23:38"},
+		{2019, "立春", "2019-02-04 11:14"},
+		{2019, "清明", "2019-04-05 09:51"},
+		{2019, "冬至", "2019-12-22 12:19"},
+		{2020, "惊蛰", "2020-03-05 10:56"},
+		{2020, "夏至", "2020-06-21 05:43"},
+		{2021, "立春", "2021-02-03 22:58"},
+		{2024, "春分", "2024-03-20 11:06"},
+		{2100, "冬至", "2100-12-22 03:50"},
+	}
+	for _, tt := range tests {
+		c, err := CreateFromSolarTerm(tt.year, tt.name, shanghai)
+		as.NoError(err)
+		as.Equal(tt.want, c.Format("2006-01-02 15:04"), "%d %s", tt.year, tt.name)
+	}
+
+	_, err := CreateFromSolarTerm(2019, "春节", shanghai)
+	as.Equal(ErrSolarTermName, err)
+
+	terms := SolarTerms(2019, shanghai)
+	as.Len(terms, 24)
+	for i := 1; i < len(terms); i++ {
+		as.True(terms[i].Time.After(terms[i-1].Time))
+	}
+}
+
+func TestCarbon_SolarTerm(t *testing.T) {
+	as := assert.New(t)
+	shanghai := time.FixedZone("CST", 8*3600)
+
+	c := Create(2019, 2, 4, 8, 0, 0, shanghai)
+	as.Equal("立春", c.SolarTerm().Name)
+	as.True(c.IsSolarTerm())
+	as.Equal("雨水", c.NextSolarTerm().Name)
+	as.Equal("2019-02-19", c.NextSolarTerm().Time.ToDateString())
+
+	c = Create(2019, 4, 12, 8, 0, 0, shanghai)
+	as.Equal("清明", c.SolarTerm().Name)
+	as.False(c.IsSolarTerm())
+	as.Equal("谷雨", c.NextSolarTerm().Name)
+
+	c = Create(2020, 1, 3, 8, 0, 0, shanghai)
+	as.Equal("冬至", c.SolarTerm().Name)
+	as.Equal(2019, c.SolarTerm().Time.Year)
+	as.Equal("小寒", c.NextSolarTerm().Name)
+
+	c = Create(2019, 12, 25, 8, 0, 0, shanghai)
+	as.Equal("小寒", c.NextSolarTerm().Name)
+	as.Equal(2020, c.NextSolarTerm().Time.Year)
+
+	// 超出 UnixNano 范围(1678-2262 年)的时间
+	for _, year := range []int{1600, 2300} {
+		c = Create(year, 4, 12, 8, 0, 0, shanghai)
+		as.Equal("清明", c.SolarTerm().Name, year)
+		as.Equal(year, c.SolarTerm().Time.Year, year)
+		as.Equal("谷雨", c.NextSolarTerm().Name, year)
+	}
+}
@@ -0,0 +1,72 @@
+package carbon
+
+import "time"
+
+// dateOf 返回当前时区 year 年 month 月 day 日零时，day 超出月份天数时顺延
+func (c *Carbon) dateOf(year int, month time.Month, day int) *Carbon {
+	return CreateFromGo(time.Date(year, month, day, 0, 0, 0, 0, c.time.Location()))
+}
+
+// Next 返回下一个星期 weekday 的零时，不包括当天
+func (c *Carbon) Next(weekday time.Weekday) *Carbon {
+	y, m, d := c.time.Date()
+	days := (int(weekday) - int(c.time.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return c.dateOf(y, m, d+days)
+}
+
+// Previous 返回上一个星期 weekday 的零时，不包括当天
+func (c *Carbon) Previous(weekday time.Weekday) *Carbon {
+	y, m, d := c.time.Date()
+	days := (int(c.time.Weekday()) - int(weekday) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return c.dateOf(y, m, d-days)
+}
+
+// nthFrom 返回从 first 开始第 n 个星期 weekday，超过 end 时返回 nil
+func nthFrom(first, end time.Time, n int, weekday time.Weekday) *Carbon {
+	if n < 1 {
+		return nil
+	}
+	days := (int(weekday)-int(first.Weekday())+7)%7 + (n-1)*7
+	date := first.AddDate(0, 0, days)
+	if !date.Before(end) {
+		return nil
+	}
+	return CreateFromGo(date)
+}
+
+// FirstOfMonth 返回当月第一个星期 weekday 的零时
+func (c *Carbon) FirstOfMonth(weekday time.Weekday) *Carbon {
+	return c.NthOfMonth(1, weekday)
+}
+
+// LastOfMonth 返回当月最后一个星期 weekday 的零时
+func (c *Carbon) LastOfMonth(weekday time.Weekday) *Carbon {
+	last := c.dateOf(c.time.Year(), c.time.Month()+1, 0)
+	days := (int(last.time.Weekday()) - int(weekday) + 7) % 7
+	return CreateFromGo(last.time.AddDate(0, 0, -days))
+}
+
+// NthOfMonth 返回当月第 n 个星期 weekday 的零时，不存在时返回 nil
+func (c *Carbon) NthOfMonth(n int, weekday time.Weekday) *Carbon {
+	first := c.dateOf(c.time.Year(), c.time.Month(), 1).time
+	return nthFrom(first, first.AddDate(0, 1, 0), n, weekday)
+}
+
+// NthOfQuarter 返回当季第 n 个星期 weekday 的零时，不存在时返回 nil
+func (c *Carbon) NthOfQuarter(n int, weekday time.Weekday) *Carbon {
+	month := time.Month((int(c.time.Month())-1)/3*3 + 1)
+	first := c.dateOf(c.time.Year(), month, 1).time
+	return nthFrom(first, first.AddDate(0, 3, 0), n, weekday)
+}
+
+// NthOfYear 返回当年第 n 个星期 weekday 的零时，不存在时返回 nil
+func (c *Carbon) NthOfYear(n int, weekday time.Weekday) *Carbon {
+	first := c.dateOf(c.time.Year(), time.January, 1).time
+	return nthFrom(first, first.AddDate(1, 0, 0), n, weekday)
+}
@@ -0,0 +1,51 @@
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarbon_NextPrevious(t *testing.T) {
+	as := assert.New(t)
+	// 2019-04-12 是星期五
+	c := Create(2019, 4, 12, 15, 4, 5, time.Local)
+
+	as.Equal("2019-04-19 00:00:00", c.Next(Friday).String())
+	as.Equal("2019-04-15 00:00:00", c.Next(Monday).String())
+	as.Equal("2019-04-13 00:00:00", c.Next(Saturday).String())
+	as.Equal("2019-04-05 00:00:00", c.Previous(Friday).String())
+	as.Equal("2019-04-11 00:00:00", c.Previous(Thursday).String())
+	as.Equal("2019-04-06 00:00:00", c.Previous(Saturday).String())
+	// 原实例不变
+	as.Equal("2019-04-12 15:04:05", c.String())
+	as.True(c.IsFriday())
+}
+
+func TestCarbon_NthOfMonth(t *testing.T) {
+	as := assert.New(t)
+	c := Create(2019, 4, 12, 15, 4, 5, time.Local)
+
+	as.Equal("2019-04-01", c.FirstOfMonth(Monday).ToDateString())
+	as.Equal("2019-04-07", c.FirstOfMonth(Sunday).ToDateString())
+	as.Equal("2019-04-09", c.NthOfMonth(2, Tuesday).ToDateString())
+	as.Equal("2019-04-29", c.NthOfMonth(5, Monday).ToDateString())
+	as.Nil(c.NthOfMonth(5, Friday))
+	as.Nil(c.NthOfMonth(0, Friday))
+	as.Equal("2019-04-30", c.LastOfMonth(Tuesday).ToDateString())
+	as.Equal("2019-04-26", c.LastOfMonth(Friday).ToDateString())
+	as.Equal("2019-02-28", Create(2019, 2, 1, 0, 0, 0, time.Local).LastOfMonth(Thursday).ToDateString())
+}
+
+func TestCarbon_NthOfQuarterYear(t *testing.T) {
+	as := assert.New(t)
+	c := Create(2019, 5, 20, 15, 4, 5, time.Local)
+
+	as.Equal("2019-04-05", c.NthOfQuarter(1, Friday).ToDateString())
+	as.Equal("2019-06-28", c.NthOfQuarter(13, Friday).ToDateString())
+	as.Nil(c.NthOfQuarter(14, Friday))
+	as.Equal("2019-01-01", c.NthOfYear(1, Tuesday).ToDateString())
+	as.Equal("2019-12-31", c.NthOfYear(53, Tuesday).ToDateString())
+	as.Nil(c.NthOfYear(53, Wednesday))
+}